@@ -0,0 +1,346 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/zorahscope/pokedexcli/internal/pokeapi"
+)
+
+// ownedPokemon is a pokemon the trainer has caught along with any
+// trainer-assigned details. The competitive details, Item through Moves, are
+// only set by importing a team; Item, Ability, Nature and Moves hold API names.
+// Pokemon is saved by name alone, see MarshalJSON.
+type ownedPokemon struct {
+	ID             int             `json:"id"`
+	Pokemon        pokeapi.Pokemon `json:"-"`
+	Nickname       string          `json:"nickname,omitempty"`
+	CaughtAt       time.Time       `json:"caught_at"`
+	CaughtLocation string          `json:"caught_location,omitempty"`
+	Item           string          `json:"item,omitempty"`
+	Ability        string          `json:"ability,omitempty"`
+	Nature         string          `json:"nature,omitempty"`
+	EVs            statSpread      `json:"evs"`
+	IVs            *statSpread     `json:"ivs,omitempty"`
+	Moves          []string        `json:"moves,omitempty"`
+}
+
+// ivs returns the pokemon's IVs. Pokemon without any set are assumed to have
+// perfect IVs, as Showdown does.
+func (p ownedPokemon) ivs() statSpread {
+	if p.IVs == nil {
+		return perfectIVs()
+	}
+	return *p.IVs
+}
+
+// displayName returns the nickname followed by the species name, or just the
+// species name when no nickname has been given.
+func (p ownedPokemon) displayName() string {
+	if p.Nickname == "" {
+		return p.Pokemon.Name
+	}
+	return fmt.Sprintf("%v (%v)", p.Nickname, p.Pokemon.Name)
+}
+
+// notPokemonError reports a name the API has no pokemon for. It matches
+// pokeapi.ErrNotFound with errors.Is.
+type notPokemonError struct {
+	name string
+}
+
+func (e notPokemonError) Error() string {
+	return e.name + " is not a pokemon"
+}
+
+func (e notPokemonError) Unwrap() error {
+	return pokeapi.ErrNotFound
+}
+
+// lookupPokemon returns a caught pokemon by name or nickname, falling back to
+// fetching it from the API so commands also work for uncaught pokemon.
+func lookupPokemon(ctx context.Context, config *commandConfig, name string) (pokeapi.Pokemon, error) {
+	if loc, ok := config.storage.find(name); ok {
+		return config.storage.get(loc).Pokemon, nil
+	}
+	pkmn, err := pokeapi.GetPokemon(ctx, name)
+	if errors.Is(err, pokeapi.ErrNotFound) {
+		return pkmn, notPokemonError{name: name}
+	}
+	if err != nil {
+		return pkmn, fmt.Errorf("error getting data from API: %w", err)
+	}
+	return pkmn, nil
+}
+
+// undoAction records how to revert the last destructive collection command.
+// revert returns an error when the command can't be undone any more, in
+// which case nothing is changed.
+type undoAction struct {
+	description string
+	revert      func(config *commandConfig) error
+}
+
+// findOwned looks up a caught pokemon by species name or nickname and prints
+// a message when it can't be found.
+func (config *commandConfig) findOwned(args []string) (location, bool) {
+	if len(args) == 0 {
+		fmt.Println("No pokemon selected! Please try again")
+		return location{}, false
+	}
+	loc, ok := config.storage.find(args[0])
+	if !ok {
+		fmt.Println("you have not caught that pokemon")
+	}
+	return loc, ok
+}
+
+// changed records that a command changed the collection, so the trainer is
+// saved once it finishes. The last undo is forgotten, since reverting it now
+// could also revert this change.
+func (config *commandConfig) changed() {
+	config.dirty = true
+	config.lastUndo = nil
+}
+
+// confirm asks the user a yes/no question and reports whether they agreed.
+// Anything other than an explicit yes is treated as a no.
+func confirm(config *commandConfig, question string) bool {
+	if config.input == nil {
+		return false
+	}
+	fmt.Printf("%v [y/N]: ", question)
+	if !config.input.Scan() {
+		return false
+	}
+	answer := strings.ToLower(strings.TrimSpace(config.input.Text()))
+	return answer == "y" || answer == "yes"
+}
+
+// parseBox parses a PC box number, printing a message when it is invalid.
+func parseBox(arg string) (int, bool) {
+	box, err := strconv.Atoi(arg)
+	if err != nil || !validBox(box) {
+		fmt.Printf("box must be a number from 1 to %d\n", boxCount)
+		return 0, false
+	}
+	return box, true
+}
+
+// putBack returns a pokemon to where it was kept before. When that box has
+// filled up since, it goes wherever there's room instead.
+func (config *commandConfig) putBack(loc location, owned ownedPokemon) error {
+	if !config.storage.full(loc.box) {
+		config.storage.insert(loc, owned)
+		return nil
+	}
+	to, err := config.storage.add(owned)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Your %v is full, so %v was put in %v\n", loc, owned.displayName(), to)
+	return nil
+}
+
+func commandRelease(ctx context.Context, config *commandConfig, args []string) error {
+	loc, ok := config.findOwned(args)
+	if !ok {
+		return nil
+	}
+	owned := *config.storage.get(loc)
+	if !confirm(config, fmt.Sprintf("Release %v back into the wild?", owned.displayName())) {
+		fmt.Println("Release cancelled")
+		return nil
+	}
+
+	config.storage.remove(loc)
+	config.changed()
+	config.lastUndo = &undoAction{
+		description: fmt.Sprintf("release of %v", owned.displayName()),
+		revert: func(config *commandConfig) error {
+			return config.putBack(loc, owned)
+		},
+	}
+	fmt.Printf("%v was released. Bye bye, %v!\n", owned.displayName(), owned.Pokemon.Name)
+	return nil
+}
+
+func commandNickname(ctx context.Context, config *commandConfig, args []string) error {
+	loc, ok := config.findOwned(args)
+	if !ok {
+		return nil
+	}
+	owned := config.storage.get(loc)
+	id, previous := owned.ID, owned.Nickname
+	owned.Nickname = strings.Join(args[1:], " ")
+	config.changed()
+	config.lastUndo = &undoAction{
+		description: fmt.Sprintf("nickname of %v", owned.Pokemon.Name),
+		revert: func(config *commandConfig) error {
+			if loc, ok := config.storage.findID(id); ok {
+				config.storage.get(loc).Nickname = previous
+			}
+			return nil
+		},
+	}
+
+	if owned.Nickname == "" {
+		fmt.Printf("%v's nickname was cleared\n", owned.Pokemon.Name)
+		return nil
+	}
+	fmt.Printf("%v is now known as %v\n", owned.Pokemon.Name, owned.Nickname)
+	return nil
+}
+
+func commandTransfer(ctx context.Context, config *commandConfig, args []string) error {
+	from, ok := config.findOwned(args)
+	if !ok {
+		return nil
+	}
+	box, ok := config.storage.firstOpenBox()
+	if len(args) > 1 {
+		if box, ok = parseBox(args[1]); !ok {
+			return nil
+		}
+	} else if !ok {
+		fmt.Println("every PC box is full")
+		return nil
+	}
+	if from.box == box {
+		fmt.Printf("%v is already in %v\n", config.storage.get(from).displayName(), from)
+		return nil
+	}
+
+	owned := *config.storage.get(from)
+	if !confirm(config, fmt.Sprintf("Transfer %v to box %d?", owned.displayName(), box)) {
+		fmt.Println("Transfer cancelled")
+		return nil
+	}
+	to, err := config.storage.move(from, box)
+	if err != nil {
+		fmt.Println(err)
+		return nil
+	}
+	config.changed()
+	config.lastUndo = &undoAction{
+		description: fmt.Sprintf("transfer of %v", owned.displayName()),
+		revert: func(config *commandConfig) error {
+			loc, ok := config.storage.findID(owned.ID)
+			if !ok {
+				return fmt.Errorf("%v is no longer in your collection", owned.displayName())
+			}
+			config.storage.remove(loc)
+			return config.putBack(from, owned)
+		},
+	}
+	fmt.Printf("%v was transferred to %v\n", owned.displayName(), to)
+	return nil
+}
+
+func commandUndo(ctx context.Context, config *commandConfig, args []string) error {
+	if config.lastUndo == nil {
+		fmt.Println("nothing to undo")
+		return nil
+	}
+	if err := config.lastUndo.revert(config); err != nil {
+		fmt.Printf("Can't undo the %v, %v\n", config.lastUndo.description, err)
+		return nil
+	}
+	fmt.Printf("Undid %v\n", config.lastUndo.description)
+	config.changed()
+	return nil
+}
+
+func commandParty(ctx context.Context, config *commandConfig, args []string) error {
+	fmt.Printf("Your Party (%d/%d):\n", len(config.storage.party), partySize)
+	printSlots(config.storage.party)
+	return nil
+}
+
+func commandBox(ctx context.Context, config *commandConfig, args []string) error {
+	if len(args) == 0 {
+		for box := 1; box <= boxCount; box++ {
+			fmt.Printf("  Box %d: %d/%d\n", box, len(*config.storage.slots(box)), boxCapacity)
+		}
+		return nil
+	}
+	box, ok := parseBox(args[0])
+	if !ok {
+		return nil
+	}
+	slots := *config.storage.slots(box)
+	fmt.Printf("Box %d (%d/%d):\n", box, len(slots), boxCapacity)
+	printSlots(slots)
+	return nil
+}
+
+func commandDeposit(ctx context.Context, config *commandConfig, args []string) error {
+	from, ok := config.findOwned(args)
+	if !ok {
+		return nil
+	}
+	if from.box != 0 {
+		fmt.Printf("%v is not in your party\n", config.storage.get(from).displayName())
+		return nil
+	}
+	if len(config.storage.party) == 1 {
+		fmt.Println("you can't deposit your last party pokemon")
+		return nil
+	}
+	box, ok := config.storage.firstOpenBox()
+	if len(args) > 1 {
+		if box, ok = parseBox(args[1]); !ok {
+			return nil
+		}
+	} else if !ok {
+		fmt.Println("every PC box is full")
+		return nil
+	}
+
+	name := config.storage.get(from).displayName()
+	to, err := config.storage.move(from, box)
+	if err != nil {
+		fmt.Println(err)
+		return nil
+	}
+	config.changed()
+	fmt.Printf("%v was deposited in %v\n", name, to)
+	return nil
+}
+
+func commandWithdraw(ctx context.Context, config *commandConfig, args []string) error {
+	from, ok := config.findOwned(args)
+	if !ok {
+		return nil
+	}
+	if from.box == 0 {
+		boxed, ok := config.storage.findIn(args[0], 1, boxCount)
+		if !ok {
+			fmt.Printf("%v is already in your party\n", config.storage.get(from).displayName())
+			return nil
+		}
+		from = boxed
+	}
+
+	name := config.storage.get(from).displayName()
+	if _, err := config.storage.move(from, 0); err != nil {
+		fmt.Println(err)
+		return nil
+	}
+	config.changed()
+	fmt.Printf("%v was withdrawn to your party\n", name)
+	return nil
+}
+
+func printSlots(slots []ownedPokemon) {
+	if len(slots) == 0 {
+		fmt.Println("  - <empty>")
+	}
+	for i, owned := range slots {
+		fmt.Printf("  %d. %v\n", i+1, owned.displayName())
+	}
+}
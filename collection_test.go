@@ -0,0 +1,161 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/zorahscope/pokedexcli/internal/pokeapi"
+)
+
+// answering returns a config whose confirmation prompts read answers.
+func answering(answers ...string) *commandConfig {
+	input := strings.NewReader(strings.Join(answers, "\n") + "\n")
+	return &commandConfig{input: bufio.NewScanner(input)}
+}
+
+func TestReleaseUndo(t *testing.T) {
+	config := answering("y")
+	config.storage.add(newOwned(1, "pidgey"))
+	config.storage.add(newOwned(2, "rattata"))
+
+	commandRelease(context.Background(), config, []string{"pidgey"})
+	if _, ok := config.storage.findID(1); ok {
+		t.Fatal("expected pidgey to be released")
+	}
+	commandUndo(context.Background(), config, nil)
+	if loc, ok := config.storage.findID(1); !ok || loc != (location{box: 0, index: 0}) {
+		t.Errorf("expected pidgey back at the front of the party, got %v, %v", loc, ok)
+	}
+	if config.lastUndo != nil {
+		t.Error("expected the undo to be used up")
+	}
+}
+
+func TestReleaseUndoIntoFullParty(t *testing.T) {
+	config := answering("y")
+	for id := 1; id <= partySize; id++ {
+		config.storage.add(newOwned(id, "pidgey"))
+	}
+	commandRelease(context.Background(), config, []string{"pidgey"})
+	config.storage.add(newOwned(partySize+1, "rattata"))
+
+	commandUndo(context.Background(), config, nil)
+	if len(config.storage.party) != partySize {
+		t.Errorf("expected the party to stay at %d, got %d", partySize, len(config.storage.party))
+	}
+	if loc, ok := config.storage.findID(1); !ok || loc.box != 1 {
+		t.Errorf("expected the released pidgey in box 1, got %v, %v", loc, ok)
+	}
+}
+
+func TestTransferUndoIntoFullBox(t *testing.T) {
+	config := answering("y")
+	for id := 1; id <= boxCapacity; id++ {
+		config.storage.boxes[0] = append(config.storage.boxes[0], newOwned(id, "zubat"))
+	}
+	commandTransfer(context.Background(), config, []string{"zubat", "2"})
+	config.storage.boxes[0] = append(config.storage.boxes[0], newOwned(boxCapacity+1, "geodude"))
+
+	commandUndo(context.Background(), config, nil)
+	if n := len(config.storage.boxes[0]); n != boxCapacity {
+		t.Errorf("expected box 1 to stay at %d, got %d", boxCapacity, n)
+	}
+	if loc, ok := config.storage.findID(1); !ok || loc.box != 0 {
+		t.Errorf("expected the zubat in the party, got %v, %v", loc, ok)
+	}
+}
+
+func TestNicknameUndo(t *testing.T) {
+	config := answering()
+	config.storage.add(newOwned(1, "pidgey"))
+
+	commandNickname(context.Background(), config, []string{"pidgey", "sky", "king"})
+	if got := config.storage.party[0].Nickname; got != "sky king" {
+		t.Fatalf("expected nickname sky king, got %q", got)
+	}
+	commandUndo(context.Background(), config, nil)
+	if got := config.storage.party[0].Nickname; got != "" {
+		t.Errorf("expected the nickname to be cleared by undo, got %q", got)
+	}
+}
+
+func TestUndoRefusedWhenStorageFull(t *testing.T) {
+	config := answering("y")
+	config.storage.add(newOwned(1, "pidgey"))
+	commandRelease(context.Background(), config, []string{"pidgey"})
+	for id := 2; ; id++ {
+		if _, err := config.storage.add(newOwned(id, "rattata")); err != nil {
+			break
+		}
+	}
+
+	commandUndo(context.Background(), config, nil)
+	if _, ok := config.storage.findID(1); ok {
+		t.Error("expected the release not to be undone with every box full")
+	}
+	if config.lastUndo == nil {
+		t.Error("expected the undo to be kept after it failed")
+	}
+}
+
+func TestNicknameKeepsCase(t *testing.T) {
+	config := answering()
+	config.locale.reset("de")
+	config.locale.learn("pikachu", "Pikachu")
+	config.storage.add(newOwned(1, "pikachu"))
+	config.storage.add(newOwned(2, "pidgey"))
+
+	captureOutput(func() {
+		runCommand(context.Background(), config, "nickname Pikachu Sparky McSparkface")
+		runCommand(context.Background(), config, "nickname PIDGEY Sky King")
+	})
+	if got := config.storage.party[0].Nickname; got != "Sparky McSparkface" {
+		t.Errorf("expected the nickname as typed, got %q", got)
+	}
+	if got := config.storage.party[1].Nickname; got != "Sky King" {
+		t.Errorf("expected the nickname as typed, got %q", got)
+	}
+}
+
+func TestLookupPokemon(t *testing.T) {
+	serveAPI(t, map[string]string{"pokemon/pidgey": `{"id": 16, "name": "pidgey"}`})
+	var config commandConfig
+	owned := newOwned(1, "pikachu")
+	owned.Nickname = "Sparky"
+	config.storage.add(owned)
+
+	cases := []struct {
+		name     string
+		expected string
+	}{
+		{"sparky", "pikachu"},
+		{"pidgey", "pidgey"},
+	}
+	for _, c := range cases {
+		pkmn, err := lookupPokemon(context.Background(), &config, c.name)
+		if err != nil || pkmn.Name != c.expected {
+			t.Errorf("%v: expected %v, got %q, %v", c.name, c.expected, pkmn.Name, err)
+		}
+	}
+
+	_, err := lookupPokemon(context.Background(), &config, "missingno")
+	if !errors.Is(err, pokeapi.ErrNotFound) || err.Error() != "missingno is not a pokemon" {
+		t.Errorf("expected missingno not to be a pokemon, got %v", err)
+	}
+	output := captureOutput(func() {
+		for _, command := range []func(context.Context, *commandConfig, []string) error{commandWhere, commandSprite, commandLearnset} {
+			if err := command(context.Background(), &config, []string{"missingno"}); err != nil {
+				t.Errorf("expected an unknown pokemon not to be a command error, got %v", err)
+			}
+		}
+		if err := teamAdd(context.Background(), &config, []string{"missingno"}); err != nil {
+			t.Errorf("expected an unknown pokemon not to be a command error, got %v", err)
+		}
+	})
+	if output != strings.Repeat("missingno is not a pokemon\n", 4) {
+		t.Errorf("expected each command to report missingno, got:\n%v", output)
+	}
+}
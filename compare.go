@@ -0,0 +1,168 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/zorahscope/pokedexcli/internal/pokeapi"
+)
+
+const (
+	compareColumn = 20
+	compareBar    = 12
+)
+
+// formatMultiplier writes a damage multiplier like "2x" or "0.25x".
+func formatMultiplier(m float64) string {
+	return strconv.FormatFloat(m, 'f', -1, 64) + "x"
+}
+
+func commandCompare(ctx context.Context, config *commandConfig, args []string) error {
+	if len(args) < 2 {
+		fmt.Println("usage: compare <pokemon> <pokemon> [pokemon...]")
+		return nil
+	}
+	urls := make([]string, len(args))
+	for i, name := range args {
+		urls[i] = pokeapi.BaseURL + "pokemon/" + name
+	}
+	pokemon, errs := pokeapi.GetEach[pokeapi.Pokemon](ctx, pokeapi.DefaultClient, urls)
+	for i, err := range errs {
+		if errors.Is(err, pokeapi.ErrNotFound) {
+			fmt.Printf("%v is not a pokemon\n", args[i])
+			return nil
+		}
+		if err != nil {
+			fmt.Printf("error getting data from API: %v\n", err)
+			return fmt.Errorf("error getting data from API: %w", err)
+		}
+	}
+	chart, err := fetchTypeChart(ctx)
+	if err != nil {
+		fmt.Printf("error getting data from API: %v\n", err)
+		return fmt.Errorf("error getting data from API: %w", err)
+	}
+
+	types := make([][]string, len(pokemon))
+	for i, pkmn := range pokemon {
+		types[i] = config.memberTypes(pkmn)
+	}
+	printStatColumns(pokemon, types)
+	printMatchups(chart, pokemon, types)
+	printAbilityComparison(pokemon)
+	return nil
+}
+
+// printStatColumns prints each pokemon's types and base stats side by side.
+func printStatColumns(pokemon []pokeapi.Pokemon, types [][]string) {
+	row := func(label string, cell func(i int, pkmn pokeapi.Pokemon) string) {
+		line := fmt.Sprintf("%-16v", label)
+		for i, pkmn := range pokemon {
+			line += fmt.Sprintf("%-*v", compareColumn, cell(i, pkmn))
+		}
+		fmt.Println(strings.TrimRight(line, " "))
+	}
+
+	row("", func(_ int, pkmn pokeapi.Pokemon) string { return pkmn.Name })
+	row("type", func(i int, _ pokeapi.Pokemon) string { return strings.Join(types[i], "/") })
+	stats := make([]statSpread, len(pokemon))
+	for i, pkmn := range pokemon {
+		stats[i] = baseStats(pkmn)
+	}
+	for s, name := range statNames {
+		row(name, func(i int, _ pokeapi.Pokemon) string {
+			return fmt.Sprintf("%3d %v", stats[i][s], statBar(stats[i][s], compareBar))
+		})
+	}
+	row("total", func(i int, _ pokeapi.Pokemon) string { return strconv.Itoa(stats[i].total()) })
+}
+
+// printMatchups prints how effective each pokemon's same-type attacks are
+// against each of the others.
+func printMatchups(chart typeChart, pokemon []pokeapi.Pokemon, types [][]string) {
+	fmt.Println("\nMatchups:")
+	for i, attacker := range pokemon {
+		for j, defender := range pokemon {
+			if i == j {
+				continue
+			}
+			hits := make([]string, len(types[i]))
+			for k, t := range types[i] {
+				hits[k] = fmt.Sprintf("%v %v", t, formatMultiplier(chart.effectiveness(t, types[j])))
+			}
+			fmt.Printf("  %v vs %v: %v\n", attacker.Name, defender.Name, strings.Join(hits, ", "))
+		}
+	}
+}
+
+// printAbilityComparison prints the abilities every pokemon has, then those
+// some but not all of them have, grouped by who has them, then those only one
+// of them has.
+func printAbilityComparison(pokemon []pokeapi.Pokemon) {
+	owners := make(map[string][]int)
+	order := []string{}
+	for i, pkmn := range pokemon {
+		for _, a := range pkmn.Abilities {
+			name := a.Ability.Name
+			if slices.Contains(owners[name], i) {
+				continue
+			}
+			if len(owners[name]) == 0 {
+				order = append(order, name)
+			}
+			owners[name] = append(owners[name], i)
+		}
+	}
+
+	shared := []string{}
+	partial := make(map[string][]string)
+	groups := []string{}
+	for _, name := range order {
+		switch n := len(owners[name]); {
+		case n == len(pokemon):
+			shared = append(shared, name)
+		case n > 1:
+			names := make([]string, n)
+			for j, i := range owners[name] {
+				names[j] = pokemon[i].Name
+			}
+			group := strings.Join(names, ", ")
+			if len(partial[group]) == 0 {
+				groups = append(groups, group)
+			}
+			partial[group] = append(partial[group], name)
+		}
+	}
+	fmt.Println("\nAbilities:")
+	if len(shared) == 0 {
+		fmt.Println("  shared: <none>")
+	} else {
+		fmt.Printf("  shared: %v\n", strings.Join(shared, ", "))
+	}
+	for _, group := range groups {
+		fmt.Printf("  shared by %v: %v\n", group, strings.Join(partial[group], ", "))
+	}
+	for _, pkmn := range pokemon {
+		unique := []string{}
+		for _, a := range pkmn.Abilities {
+			if len(owners[a.Ability.Name]) == 1 {
+				unique = append(unique, abilityLabel(a.Ability.Name, a.IsHidden))
+			}
+		}
+		if len(unique) == 0 {
+			unique = append(unique, "<none>")
+		}
+		fmt.Printf("  only %v: %v\n", pkmn.Name, strings.Join(unique, ", "))
+	}
+}
+
+func abilityLabel(name string, hidden bool) string {
+	if hidden {
+		return name + " (hidden)"
+	}
+	return name
+}
@@ -0,0 +1,51 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/zorahscope/pokedexcli/internal/pokeapi"
+)
+
+func TestFormatMultiplier(t *testing.T) {
+	for m, want := range map[float64]string{4: "4x", 1: "1x", 0.5: "0.5x", 0.25: "0.25x", 0: "0x"} {
+		if got := formatMultiplier(m); got != want {
+			t.Errorf("formatMultiplier(%v) = %q, expected %q", m, got, want)
+		}
+	}
+}
+
+func TestPrintAbilityComparison(t *testing.T) {
+	pokemon := []pokeapi.Pokemon{
+		withAbilities("gyarados", "intimidate", "moxie"),
+		withAbilities("salamence", "intimidate", "moxie"),
+		withAbilities("arcanine", "intimidate", "flash-fire"),
+		withAbilities("staraptor", "intimidate", "reckless"),
+	}
+	output := captureOutput(func() { printAbilityComparison(pokemon) })
+	expected := `
+Abilities:
+  shared: intimidate
+  shared by gyarados, salamence: moxie
+  only gyarados: <none>
+  only salamence: <none>
+  only arcanine: flash-fire (hidden)
+  only staraptor: reckless (hidden)
+`
+	if output != expected {
+		t.Errorf("expected:%v\ngot:%v", expected, output)
+	}
+}
+
+// withAbilities returns a pokemon with the given abilities, the last of them
+// hidden.
+func withAbilities(name string, abilities ...string) pokeapi.Pokemon {
+	pkmn := pokeapi.Pokemon{Name: name}
+	for i, ability := range abilities {
+		pkmn.Abilities = append(pkmn.Abilities, struct {
+			Ability  pokeapi.Ref[pokeapi.Ability] `json:"ability"`
+			IsHidden bool                         `json:"is_hidden"`
+			Slot     int                          `json:"slot"`
+		}{Ability: pokeapi.Ref[pokeapi.Ability]{Name: ability}, IsHidden: i == len(abilities)-1, Slot: i + 1})
+	}
+	return pkmn
+}
@@ -0,0 +1,243 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/zorahscope/pokedexcli/internal/pokeapi"
+)
+
+// encounterRow is one way of finding a pokemon in a version of the game, with
+// the chances of its encounter details added up and their level ranges
+// merged. name is the pokemon when exploring an area, or the area when
+// looking up where a pokemon lives.
+type encounterRow struct {
+	name     string
+	method   string
+	chance   int
+	minLevel int
+	maxLevel int
+}
+
+func (r encounterRow) levels() string {
+	if r.minLevel == r.maxLevel {
+		return fmt.Sprint(r.minLevel)
+	}
+	return fmt.Sprintf("%d-%d", r.minLevel, r.maxLevel)
+}
+
+// versionEncounters holds encounters for one version, along with the rate
+// each encounter method triggers at when they're known.
+type versionEncounters struct {
+	version string
+	rows    []encounterRow
+	rates   map[string]int
+}
+
+// encounterTables groups encounters by version, keeping only those matching
+// version and method when they're set. Versions are listed in the order
+// they're added.
+type encounterTables struct {
+	version   string
+	method    string
+	tables    []versionEncounters
+	byVersion map[string]int
+}
+
+func newEncounterTables(version, method string) *encounterTables {
+	return &encounterTables{version: version, method: method, byVersion: make(map[string]int)}
+}
+
+// add merges the encounter details of detail into a row per method for name.
+func (e *encounterTables) add(name string, detail pokeapi.VersionEncounterDetail) {
+	if e.version != "" && detail.Version.Name != e.version {
+		return
+	}
+	rows := make(map[string]*encounterRow)
+	order := []string{}
+	for _, d := range detail.EncounterDetails {
+		if e.method != "" && d.Method.Name != e.method {
+			continue
+		}
+		row, ok := rows[d.Method.Name]
+		if !ok {
+			row = &encounterRow{name: name, method: d.Method.Name, minLevel: d.MinLevel, maxLevel: d.MaxLevel}
+			rows[d.Method.Name] = row
+			order = append(order, d.Method.Name)
+		}
+		row.chance += d.Chance
+		row.minLevel = min(row.minLevel, d.MinLevel)
+		row.maxLevel = max(row.maxLevel, d.MaxLevel)
+	}
+	if len(order) == 0 {
+		return
+	}
+	i, ok := e.byVersion[detail.Version.Name]
+	if !ok {
+		i = len(e.tables)
+		e.byVersion[detail.Version.Name] = i
+		e.tables = append(e.tables, versionEncounters{version: detail.Version.Name, rates: make(map[string]int)})
+	}
+	for _, method := range order {
+		e.tables[i].rows = append(e.tables[i].rows, *rows[method])
+	}
+}
+
+// setRate records how often method triggers in version, if that version has
+// any encounters.
+func (e *encounterTables) setRate(version, method string, rate int) {
+	if i, ok := e.byVersion[version]; ok {
+		e.tables[i].rates[method] = rate
+	}
+}
+
+// sorted returns the tables with their rows sorted by method, then by chance.
+func (e *encounterTables) sorted() []versionEncounters {
+	for _, table := range e.tables {
+		sort.SliceStable(table.rows, func(i, j int) bool {
+			a, b := table.rows[i], table.rows[j]
+			if a.method != b.method {
+				return a.method < b.method
+			}
+			if a.chance != b.chance {
+				return a.chance > b.chance
+			}
+			return a.name < b.name
+		})
+	}
+	return e.tables
+}
+
+// encounterTable groups the pokemon encounters in area by version.
+func encounterTable(area pokeapi.LocationArea, version, method string) []versionEncounters {
+	tables := newEncounterTables(version, method)
+	for _, encounter := range area.PokemonEncounters {
+		for _, detail := range encounter.VersionDetails {
+			tables.add(encounter.Pokemon.Name, detail)
+		}
+	}
+	for _, rate := range area.EncounterMethodRates {
+		for _, detail := range rate.VersionDetails {
+			tables.setRate(detail.Version.Name, rate.EncounterMethod.Name, detail.Rate)
+		}
+	}
+	return tables.sorted()
+}
+
+// printEncounterTables prints a table per version, using heading as the title
+// of the name column and label to display each name.
+func printEncounterTables(tables []versionEncounters, heading string, label func(string) string) {
+	for _, table := range tables {
+		fmt.Printf("\n%v:\n", table.version)
+		fmt.Printf("  %-24v %-18v %6v  %v\n", heading, "Method", "Chance", "Levels")
+		for _, row := range table.rows {
+			fmt.Printf("  %-24v %-18v %5d%%  %v\n", label(row.name), row.method, row.chance, row.levels())
+		}
+		methods := make([]string, 0, len(table.rates))
+		for method := range table.rates {
+			methods = append(methods, method)
+		}
+		sort.Strings(methods)
+		rates := make([]string, 0, len(methods))
+		for _, method := range methods {
+			rates = append(rates, fmt.Sprintf("%v %d%%", method, table.rates[method]))
+		}
+		if len(rates) > 0 {
+			fmt.Println("  Encounter rates: " + strings.Join(rates, ", "))
+		}
+	}
+}
+
+// encounterVersions lists every version with encounters in area.
+func encounterVersions(area pokeapi.LocationArea) []string {
+	versions := []string{}
+	for _, table := range encounterTable(area, "", "") {
+		versions = append(versions, table.version)
+	}
+	return versions
+}
+
+func commandExplore(ctx context.Context, config *commandConfig, args []string) error {
+	args, flags := parseFlags(args, "version", "method")
+	if len(args) == 0 {
+		fmt.Println("Empty argument! Please try again")
+		return nil
+	}
+	area, err := fetchLocationArea(ctx, config, args[0])
+	if err != nil {
+		fmt.Println(err)
+		return err
+	}
+
+	fmt.Println("Exploring " + config.locale.display(area.Name) + "...")
+	tables := encounterTable(area, flags.get("version", config.versionName()), flags.get("method", ""))
+	if len(tables) == 0 {
+		fmt.Println("No pokemon match those filters here")
+		if versions := encounterVersions(area); len(versions) > 0 {
+			fmt.Println("Versions with encounters: " + strings.Join(versions, ", "))
+		}
+		return nil
+	}
+	names := []string{}
+	for _, table := range tables {
+		for _, row := range table.rows {
+			names = append(names, row.name)
+		}
+	}
+	if err := config.markSeenPokemon(ctx, encounterRefs(area, names)); err != nil {
+		fmt.Printf("Some pokemon here are marked seen by form rather than species, %v\n", err)
+	}
+	if err := config.locale.localizePokemon(ctx, names); err != nil {
+		fmt.Printf("error getting data from API: %v\n", err)
+		return fmt.Errorf("error getting data from API: %w", err)
+	}
+	printEncounterTables(tables, "Pokemon", config.locale.display)
+	return nil
+}
+
+// fetchLocationArea fetches a location area and makes it the current area, so
+// pokemon caught afterwards record where they were found.
+func fetchLocationArea(ctx context.Context, config *commandConfig, name string) (pokeapi.LocationArea, error) {
+	if config.exploreURL == "" {
+		config.exploreURL = pokeapi.BaseURL + "location-area/"
+	}
+	area, err := pokeapi.GetFromAPI[pokeapi.LocationArea](ctx, config.exploreURL+name)
+	if err != nil {
+		return area, fmt.Errorf("error getting data from API: %w", err)
+	}
+	config.area = area.Name
+	if name, ok := localName(area.Names, config.locale.language); ok {
+		config.locale.learn(area.Name, name)
+	}
+	return area, nil
+}
+
+// encounterRefs returns the references to the pokemon encountered in area,
+// limited to names when it's set.
+func encounterRefs(area pokeapi.LocationArea, names []string) []pokeapi.Ref[pokeapi.Pokemon] {
+	refs := []pokeapi.Ref[pokeapi.Pokemon]{}
+	for _, encounter := range area.PokemonEncounters {
+		if names == nil || slices.Contains(names, encounter.Pokemon.Name) {
+			refs = append(refs, encounter.Pokemon)
+		}
+	}
+	return refs
+}
+
+// exploreArea returns the names of the pokemon found in a location area,
+// marking each of them as seen. Failing to look up their species is returned
+// as a warning, alongside the names.
+func exploreArea(ctx context.Context, config *commandConfig, name string) (names []string, warning error, err error) {
+	area, err := fetchLocationArea(ctx, config, name)
+	if err != nil {
+		return nil, nil, err
+	}
+	refs := encounterRefs(area, nil)
+	for _, ref := range refs {
+		names = append(names, ref.Name)
+	}
+	return names, config.markSeenPokemon(ctx, refs), nil
+}
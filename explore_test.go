@@ -0,0 +1,68 @@
+package main
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/zorahscope/pokedexcli/internal/pokeapi"
+)
+
+const testLocationArea = `{
+	"name": "route-1-area",
+	"encounter_method_rates": [
+		{"encounter_method": {"name": "walk"}, "version_details": [{"rate": 25, "version": {"name": "red"}}]}
+	],
+	"pokemon_encounters": [
+		{"pokemon": {"name": "pidgey"}, "version_details": [
+			{"version": {"name": "red"}, "encounter_details": [
+				{"chance": 30, "min_level": 2, "max_level": 3, "method": {"name": "walk"}},
+				{"chance": 20, "min_level": 4, "max_level": 5, "method": {"name": "walk"}}
+			]},
+			{"version": {"name": "blue"}, "encounter_details": [
+				{"chance": 15, "min_level": 2, "max_level": 2, "method": {"name": "walk"}}
+			]}
+		]},
+		{"pokemon": {"name": "rattata"}, "version_details": [
+			{"version": {"name": "red"}, "encounter_details": [
+				{"chance": 45, "min_level": 2, "max_level": 4, "method": {"name": "walk"}},
+				{"chance": 5, "min_level": 5, "max_level": 5, "method": {"name": "old-rod"}}
+			]}
+		]}
+	]
+}`
+
+func TestEncounterTable(t *testing.T) {
+	var area pokeapi.LocationArea
+	if err := json.Unmarshal([]byte(testLocationArea), &area); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	tables := encounterTable(area, "", "")
+	if len(tables) != 2 || tables[0].version != "red" || tables[1].version != "blue" {
+		t.Fatalf("expected tables for red then blue, got %+v", tables)
+	}
+	want := []encounterRow{
+		{name: "rattata", method: "old-rod", chance: 5, minLevel: 5, maxLevel: 5},
+		{name: "pidgey", method: "walk", chance: 50, minLevel: 2, maxLevel: 5},
+		{name: "rattata", method: "walk", chance: 45, minLevel: 2, maxLevel: 4},
+	}
+	if len(tables[0].rows) != len(want) {
+		t.Fatalf("expected %d rows for red, got %+v", len(want), tables[0].rows)
+	}
+	for i, row := range tables[0].rows {
+		if row != want[i] {
+			t.Errorf("row %d: expected %+v, got %+v", i, want[i], row)
+		}
+	}
+	if tables[0].rates["walk"] != 25 {
+		t.Errorf("expected a walk rate of 25 in red, got %v", tables[0].rates)
+	}
+
+	tables = encounterTable(area, "red", "old-rod")
+	if len(tables) != 1 || len(tables[0].rows) != 1 || tables[0].rows[0].name != "rattata" {
+		t.Errorf("expected only rattata by old rod in red, got %+v", tables)
+	}
+	if tables := encounterTable(area, "yellow", ""); len(tables) != 0 {
+		t.Errorf("expected no tables for yellow, got %+v", tables)
+	}
+}
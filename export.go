@@ -0,0 +1,552 @@
+package main
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/zorahscope/pokedexcli/internal/pokeapi"
+)
+
+// exportColumns are the fields of an exported pokemon, in the order they're
+// written to CSV and Markdown.
+var exportColumns = []string{"id", "species", "nickname", "caught_at", "location", "storage", "item", "ability", "nature", "evs", "ivs", "moves"}
+
+// exportRecord is the portable form of an owned pokemon. Only the species is
+// kept, since everything else about it can be fetched from the API again.
+// EVs and IVs are written the way Showdown writes them, like "252 HP / 4 Atk".
+type exportRecord struct {
+	ID       int       `json:"id"`
+	Species  string    `json:"species"`
+	Nickname string    `json:"nickname,omitempty"`
+	CaughtAt time.Time `json:"caught_at"`
+	Location string    `json:"location,omitempty"`
+	Storage  string    `json:"storage"`
+	Item     string    `json:"item,omitempty"`
+	Ability  string    `json:"ability,omitempty"`
+	Nature   string    `json:"nature,omitempty"`
+	EVs      string    `json:"evs,omitempty"`
+	IVs      string    `json:"ivs,omitempty"`
+	Moves    []string  `json:"moves,omitempty"`
+}
+
+func (r exportRecord) fields() []string {
+	return []string{
+		strconv.Itoa(r.ID), r.Species, r.Nickname, r.CaughtAt.Format(time.RFC3339), r.Location, r.Storage,
+		r.Item, r.Ability, r.Nature, r.EVs, r.IVs, strings.Join(r.Moves, " / "),
+	}
+}
+
+// recordOf converts an owned pokemon kept in box.
+func recordOf(p ownedPokemon, box int) exportRecord {
+	r := exportRecord{
+		ID:       p.ID,
+		Species:  p.Pokemon.Name,
+		Nickname: p.Nickname,
+		CaughtAt: p.CaughtAt,
+		Location: p.CaughtLocation,
+		Storage:  location{box: box}.String(),
+		Item:     p.Item,
+		Ability:  p.Ability,
+		Nature:   p.Nature,
+		EVs:      p.EVs.format(0),
+		IVs:      p.ivs().format(maxIV),
+		Moves:    p.Moves,
+	}
+	return r
+}
+
+// sameSet reports whether r describes the same pokemon as a, ignoring where
+// they're kept. Records without a catch time, such as Showdown sets, match on
+// their other details alone.
+func sameSet(a, r exportRecord) bool {
+	if !r.CaughtAt.IsZero() && !a.CaughtAt.Equal(r.CaughtAt) {
+		return false
+	}
+	return a.Species == r.Species && a.Nickname == r.Nickname && a.Item == r.Item && a.Ability == r.Ability &&
+		a.Nature == r.Nature && a.EVs == r.EVs && a.IVs == r.IVs && slices.Equal(a.Moves, r.Moves)
+}
+
+// recordFromFields builds a record from a row whose columns are named by
+// header.
+func recordFromFields(header, row []string) (exportRecord, error) {
+	var r exportRecord
+	values := make(map[string]string, len(header))
+	for i, name := range header {
+		if i < len(row) {
+			values[strings.TrimSpace(name)] = strings.TrimSpace(row[i])
+		}
+	}
+	r.Species = values["species"]
+	r.Nickname = values["nickname"]
+	r.Location = values["location"]
+	r.Storage = values["storage"]
+	r.Item = values["item"]
+	r.Ability = values["ability"]
+	r.Nature = values["nature"]
+	r.EVs = values["evs"]
+	r.IVs = values["ivs"]
+	if moves := values["moves"]; moves != "" {
+		for _, move := range strings.Split(moves, "/") {
+			r.Moves = append(r.Moves, strings.TrimSpace(move))
+		}
+	}
+	if id := values["id"]; id != "" {
+		n, err := strconv.Atoi(id)
+		if err != nil {
+			return r, fmt.Errorf("id %q isn't a number", id)
+		}
+		r.ID = n
+	}
+	if caughtAt := values["caught_at"]; caughtAt != "" {
+		t, err := time.Parse(time.RFC3339, caughtAt)
+		if err != nil {
+			return r, fmt.Errorf("caught_at %q isn't an RFC 3339 time", caughtAt)
+		}
+		r.CaughtAt = t
+	}
+	return r, nil
+}
+
+// parseStorage parses the storage column, returning 0 for the party or the
+// PC box number.
+func parseStorage(storage string) (int, bool) {
+	if storage == "party" {
+		return 0, true
+	}
+	n, err := strconv.Atoi(strings.TrimPrefix(storage, "box "))
+	if err != nil || !validBox(n) {
+		return 0, false
+	}
+	return n, true
+}
+
+func exportRecords(s *storage) []exportRecord {
+	records := []exportRecord{}
+	for box := 0; box <= boxCount; box++ {
+		for _, owned := range *s.slots(box) {
+			records = append(records, recordOf(owned, box))
+		}
+	}
+	return records
+}
+
+// exportFormat picks the format from the --format flag, falling back to the
+// file extension.
+func exportFormat(path, flag string) (string, error) {
+	format := strings.ToLower(flag)
+	if format == "" {
+		format = strings.ToLower(strings.TrimPrefix(filepath.Ext(path), "."))
+	}
+	switch format {
+	case "json", "csv", "showdown":
+		return format, nil
+	case "md", "markdown":
+		return "md", nil
+	}
+	return "", fmt.Errorf("can't tell the format of %q, use --format=json, csv, md or showdown", path)
+}
+
+func encodeRecords(w io.Writer, records []exportRecord, format string) error {
+	switch format {
+	case "json":
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(records)
+	case "csv":
+		writer := csv.NewWriter(w)
+		writer.Write(exportColumns)
+		for _, r := range records {
+			writer.Write(r.fields())
+		}
+		writer.Flush()
+		return writer.Error()
+	case "showdown":
+		return encodeShowdown(w, records)
+	}
+	var b strings.Builder
+	b.WriteString("| " + strings.Join(exportColumns, " | ") + " |\n")
+	b.WriteString(strings.Repeat("| --- ", len(exportColumns)) + "|\n")
+	for _, r := range records {
+		fields := r.fields()
+		for i, field := range fields {
+			fields[i] = strings.ReplaceAll(field, "|", `\|`)
+		}
+		b.WriteString("| " + strings.Join(fields, " | ") + " |\n")
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func decodeRecords(r io.Reader, format string) ([]exportRecord, error) {
+	switch format {
+	case "json":
+		records := []exportRecord{}
+		if err := json.NewDecoder(r).Decode(&records); err != nil {
+			return nil, fmt.Errorf("malformed JSON: %w", err)
+		}
+		return records, nil
+	case "csv":
+		rows, err := csv.NewReader(r).ReadAll()
+		if err != nil {
+			return nil, fmt.Errorf("malformed CSV: %w", err)
+		}
+		return recordsFromRows(rows)
+	case "showdown":
+		return decodeShowdown(r)
+	}
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	rows := [][]string{}
+	for _, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimSpace(line)
+		if !strings.HasPrefix(line, "|") {
+			continue
+		}
+		row := splitMarkdownRow(line)
+		if len(rows) == 1 && strings.Trim(strings.Join(row, ""), "-: ") == "" {
+			continue
+		}
+		rows = append(rows, row)
+	}
+	return recordsFromRows(rows)
+}
+
+// splitMarkdownRow splits a table row on the pipes that aren't escaped.
+func splitMarkdownRow(line string) []string {
+	line = strings.TrimSuffix(strings.TrimPrefix(line, "|"), "|")
+	cells := []string{}
+	var cell strings.Builder
+	for i := 0; i < len(line); i++ {
+		switch {
+		case line[i] == '\\' && i+1 < len(line) && line[i+1] == '|':
+			cell.WriteByte('|')
+			i++
+		case line[i] == '|':
+			cells = append(cells, cell.String())
+			cell.Reset()
+		default:
+			cell.WriteByte(line[i])
+		}
+	}
+	return append(cells, cell.String())
+}
+
+// recordsFromRows reads records from a header row followed by data rows.
+func recordsFromRows(rows [][]string) ([]exportRecord, error) {
+	if len(rows) == 0 {
+		return []exportRecord{}, nil
+	}
+	records := make([]exportRecord, 0, len(rows)-1)
+	for i, row := range rows[1:] {
+		r, err := recordFromFields(rows[0], row)
+		if err != nil {
+			return nil, fmt.Errorf("row %d: %w", i+1, err)
+		}
+		records = append(records, r)
+	}
+	return records, nil
+}
+
+// validateRecords checks each record's fields and fetches its species,
+// rejecting the import when any species is unknown to the API or a pokemon
+// has an ability or move its species can't have.
+func validateRecords(ctx context.Context, records []exportRecord) (map[string]pokeapi.Pokemon, error) {
+	species := []string{}
+	urls := []string{}
+	for i, r := range records {
+		if r.Species == "" {
+			return nil, fmt.Errorf("entry %d has no species", i+1)
+		}
+		if r.Storage != "" {
+			if _, ok := parseStorage(r.Storage); !ok {
+				return nil, fmt.Errorf("entry %d has an unknown storage %q, expected party or box 1 to box %d", i+1, r.Storage, boxCount)
+			}
+		}
+		if _, err := r.owned(0, pokeapi.Pokemon{}); err != nil {
+			return nil, fmt.Errorf("entry %d (%v) %w", i+1, r.Species, err)
+		}
+		name := strings.ToLower(r.Species)
+		if !slices.Contains(species, name) {
+			species = append(species, name)
+			urls = append(urls, pokeapi.BaseURL+"pokemon/"+name)
+		}
+	}
+
+	pokemon, errs := pokeapi.GetEach[pokeapi.Pokemon](ctx, pokeapi.DefaultClient, urls)
+	fetched := make(map[string]pokeapi.Pokemon)
+	unknown := []string{}
+	for i, err := range errs {
+		if errors.Is(err, pokeapi.ErrNotFound) {
+			unknown = append(unknown, species[i])
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("error getting data from API: %w", err)
+		}
+		fetched[species[i]] = pokemon[i]
+	}
+	if len(unknown) > 0 {
+		return nil, fmt.Errorf("unknown species: %v", strings.Join(unknown, ", "))
+	}
+
+	for i, r := range records {
+		pkmn := fetched[strings.ToLower(r.Species)]
+		if r.Ability != "" && !hasAbility(pkmn, r.Ability) {
+			return nil, fmt.Errorf("entry %d: %v can't have the ability %v", i+1, pkmn.Name, r.Ability)
+		}
+		for _, move := range r.Moves {
+			if !canLearn(pkmn, move) {
+				return nil, fmt.Errorf("entry %d: %v can't learn %v", i+1, pkmn.Name, move)
+			}
+		}
+	}
+	return fetched, nil
+}
+
+func hasAbility(pkmn pokeapi.Pokemon, ability string) bool {
+	for _, a := range pkmn.Abilities {
+		if a.Ability.Name == ability {
+			return true
+		}
+	}
+	return false
+}
+
+func canLearn(pkmn pokeapi.Pokemon, move string) bool {
+	for _, m := range pkmn.Moves {
+		if m.Move.Name == move {
+			return true
+		}
+	}
+	return false
+}
+
+// owned converts the record into an owned pokemon of species pkmn, checking
+// its nature, EVs, IVs and number of moves.
+func (r exportRecord) owned(id int, pkmn pokeapi.Pokemon) (ownedPokemon, error) {
+	p := ownedPokemon{
+		ID:             id,
+		Pokemon:        pkmn,
+		Nickname:       r.Nickname,
+		CaughtAt:       r.CaughtAt,
+		CaughtLocation: r.Location,
+		Item:           r.Item,
+		Ability:        r.Ability,
+		Nature:         r.Nature,
+		Moves:          r.Moves,
+	}
+	if _, ok := natures[r.Nature]; r.Nature != "" && !ok {
+		return p, fmt.Errorf("has an unknown nature %q", r.Nature)
+	}
+	if len(r.Moves) > maxMoves {
+		return p, fmt.Errorf("knows %d moves, a pokemon can only know %d", len(r.Moves), maxMoves)
+	}
+	evs, err := parseSpread(r.EVs, 0)
+	if err == nil {
+		err = validEVs(evs)
+	}
+	if err != nil {
+		return p, fmt.Errorf("has invalid EVs, %w", err)
+	}
+	p.EVs = evs
+	if r.IVs != "" {
+		ivs, err := parseSpread(r.IVs, maxIV)
+		if err == nil {
+			err = validIVs(ivs)
+		}
+		if err != nil {
+			return p, fmt.Errorf("has invalid IVs, %w", err)
+		}
+		p.IVs = &ivs
+	}
+	return p, nil
+}
+
+// collectionSnapshot is a copy of a trainer's collection, used to undo an
+// import.
+type collectionSnapshot struct {
+	storage storage
+	nextID  int
+	seen    map[string]bool
+	caught  map[string]bool
+}
+
+func (config *commandConfig) snapshot() collectionSnapshot {
+	snap := collectionSnapshot{nextID: config.nextID, seen: map[string]bool{}, caught: map[string]bool{}}
+	snap.storage.party = append([]ownedPokemon{}, config.storage.party...)
+	for i, box := range config.storage.boxes {
+		snap.storage.boxes[i] = append([]ownedPokemon{}, box...)
+	}
+	for name := range config.seen {
+		snap.seen[name] = true
+	}
+	for name := range config.caught {
+		snap.caught[name] = true
+	}
+	return snap
+}
+
+func (config *commandConfig) restore(snap collectionSnapshot) {
+	config.storage = snap.storage
+	config.nextID = snap.nextID
+	config.seen = snap.seen
+	config.caught = snap.caught
+}
+
+func commandExport(ctx context.Context, config *commandConfig, args []string) error {
+	args, flags := parseFlags(args, "format")
+	path := ""
+	if len(args) > 0 {
+		path = args[0]
+	}
+	// A file's extension names its format, otherwise the output_format
+	// setting decides.
+	defaultFormat := ""
+	if filepath.Ext(path) == "" {
+		defaultFormat = config.outputFormat()
+	}
+	format, err := exportFormat(path, flags.get("format", defaultFormat))
+	if err != nil {
+		fmt.Println(err)
+		return nil
+	}
+	records := exportRecords(&config.storage)
+	if format == "showdown" {
+		// A Showdown team is the party.
+		records = records[:len(config.storage.party)]
+	}
+	if path == "" {
+		return encodeRecords(os.Stdout, records, format)
+	}
+
+	if _, err := os.Stat(path); err == nil && !flags.has("force") &&
+		!confirm(config, fmt.Sprintf("%v already exists, overwrite it?", path)) {
+		fmt.Println("Export cancelled, use --force to overwrite without asking")
+		return nil
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		fmt.Println(err)
+		return err
+	}
+	if err := encodeRecords(f, records, format); err != nil {
+		f.Close()
+		fmt.Println(err)
+		return err
+	}
+	if err := f.Close(); err != nil {
+		fmt.Println(err)
+		return err
+	}
+	fmt.Printf("Exported %d pokemon to %v\n", len(records), path)
+	return nil
+}
+
+func commandImport(ctx context.Context, config *commandConfig, args []string) error {
+	args, flags := parseFlags(args, "format", "strategy")
+	if len(args) == 0 {
+		fmt.Println("usage: import <file> [--format=json|csv|md] [--strategy=merge|replace]")
+		return nil
+	}
+	strategy := strings.ToLower(flags.get("strategy", "merge"))
+	if strategy != "merge" && strategy != "replace" {
+		fmt.Println("strategy must be merge or replace")
+		return nil
+	}
+	path := args[0]
+	format, err := exportFormat(path, flags.get("format", ""))
+	if err != nil {
+		fmt.Println(err)
+		return nil
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		fmt.Println(err)
+		return nil
+	}
+	records, err := decodeRecords(f, format)
+	f.Close()
+	if err != nil {
+		fmt.Printf("Import rejected, %v\n", err)
+		return nil
+	}
+	pokemon, err := validateRecords(ctx, records)
+	if err != nil {
+		fmt.Printf("Import rejected, %v\n", err)
+		return nil
+	}
+
+	owned := config.storage.all()
+	if strategy == "replace" && len(owned) > 0 &&
+		!confirm(config, fmt.Sprintf("Replace your %d pokemon with the %d in %v?", len(owned), len(records), path)) {
+		fmt.Println("Import cancelled")
+		return nil
+	}
+
+	snap := config.snapshot()
+	if strategy == "replace" {
+		config.storage = storage{}
+		config.caught = map[string]bool{}
+		owned = nil
+	}
+	imported, skipped := 0, 0
+	for _, r := range records {
+		pkmn := pokemon[strings.ToLower(r.Species)]
+		r.Species = pkmn.Name
+		if duplicateRecord(owned, r) {
+			skipped++
+			continue
+		}
+		config.nextID++
+		p, _ := r.owned(config.nextID, pkmn)
+		if p.CaughtAt.IsZero() {
+			p.CaughtAt = time.Now()
+		}
+		if box, ok := parseStorage(r.Storage); ok && !config.storage.full(box) {
+			config.storage.insert(location{box: box, index: boxCapacity}, p)
+		} else if _, err := config.storage.add(p); err != nil {
+			config.restore(snap)
+			fmt.Printf("Import rejected, %v\n", err)
+			return nil
+		}
+		config.markCaught(speciesName(pkmn))
+		imported++
+	}
+
+	config.changed()
+	config.lastUndo = &undoAction{
+		description: "import of " + path,
+		revert: func(config *commandConfig) error {
+			config.restore(snap)
+			return nil
+		},
+	}
+	fmt.Printf("Imported %d pokemon from %v", imported, path)
+	if skipped > 0 {
+		fmt.Printf(", skipping %d already in your collection", skipped)
+	}
+	fmt.Println()
+	return nil
+}
+
+// duplicateRecord reports whether r describes a pokemon already in owned.
+func duplicateRecord(owned []ownedPokemon, r exportRecord) bool {
+	for _, o := range owned {
+		if sameSet(recordOf(o, 0), r) {
+			return true
+		}
+	}
+	return false
+}
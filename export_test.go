@@ -0,0 +1,180 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestExportRoundTrip(t *testing.T) {
+	var s storage
+	pidgey := newOwned(1, "pidgey")
+	pidgey.Nickname = "bird | brain"
+	pidgey.CaughtAt = time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	pidgey.CaughtLocation = "route-1-area"
+	pidgey.EVs = statSpread{0, 0, 0, 0, 4, 252}
+	pidgey.Moves = []string{"gust", "quick-attack"}
+	s.add(pidgey)
+	s.boxes[2] = append(s.boxes[2], newOwned(2, "rattata"))
+	records := exportRecords(&s)
+
+	for _, format := range []string{"json", "csv", "md"} {
+		var buf bytes.Buffer
+		if err := encodeRecords(&buf, records, format); err != nil {
+			t.Fatalf("%v: unexpected error: %v", format, err)
+		}
+		decoded, err := decodeRecords(&buf, format)
+		if err != nil {
+			t.Fatalf("%v: unexpected error: %v", format, err)
+		}
+		if len(decoded) != 2 {
+			t.Fatalf("%v: expected 2 records, got %+v", format, decoded)
+		}
+		got := decoded[0]
+		if got.Species != "pidgey" || got.Nickname != "bird | brain" || got.Location != "route-1-area" || got.Storage != "party" {
+			t.Errorf("%v: unexpected record %+v", format, got)
+		}
+		if !got.CaughtAt.Equal(pidgey.CaughtAt) {
+			t.Errorf("%v: expected caught_at %v, got %v", format, pidgey.CaughtAt, got.CaughtAt)
+		}
+		if !sameSet(records[0], got) {
+			t.Errorf("%v: expected the set to survive a round trip, got %+v", format, got)
+		}
+		if decoded[1].Storage != "box 3" {
+			t.Errorf("%v: expected rattata in box 3, got %q", format, decoded[1].Storage)
+		}
+	}
+}
+
+func TestExportFormat(t *testing.T) {
+	tests := []struct {
+		path, flag, want string
+	}{
+		{"team.json", "", "json"},
+		{"team.CSV", "", "csv"},
+		{"team.markdown", "", "md"},
+		{"team.txt", "md", "md"},
+	}
+	for _, tt := range tests {
+		if got, err := exportFormat(tt.path, tt.flag); err != nil || got != tt.want {
+			t.Errorf("exportFormat(%q, %q) = %q, %v, expected %q", tt.path, tt.flag, got, err, tt.want)
+		}
+	}
+	if _, err := exportFormat("team.txt", ""); err == nil {
+		t.Error("expected an error for an unknown extension")
+	}
+}
+
+func TestImportedNicknameCanBeFound(t *testing.T) {
+	serveAPI(t, map[string]string{
+		"pokemon/pikachu": `{"id": 25, "name": "pikachu", "species": {"name": "pikachu"}}`,
+	})
+	for _, format := range []string{"json", "csv", "md"} {
+		var buf bytes.Buffer
+		records := []exportRecord{{Species: "Pikachu", Nickname: "Sparky", Storage: "party"}}
+		if err := encodeRecords(&buf, records, format); err != nil {
+			t.Fatalf("%v: unexpected error: %v", format, err)
+		}
+		path := filepath.Join(t.TempDir(), "collection."+format)
+		if err := os.WriteFile(path, buf.Bytes(), 0o644); err != nil {
+			t.Fatal(err)
+		}
+
+		var config commandConfig
+		captureOutput(func() { commandImport(context.Background(), &config, []string{path}) })
+		loc, ok := config.storage.find("sparky")
+		if !ok {
+			t.Fatalf("%v: expected the imported pikachu to be found by its nickname", format)
+		}
+		if owned := config.storage.get(loc); owned.Nickname != "Sparky" || owned.Pokemon.Name != "pikachu" {
+			t.Errorf("%v: unexpected pokemon %+v", format, owned)
+		}
+	}
+}
+
+func TestExportUsesOutputFormat(t *testing.T) {
+	var config commandConfig
+	config.storage.add(newOwned(1, "pikachu"))
+
+	output := captureOutput(func() { commandExport(context.Background(), &config, nil) })
+	if !strings.HasPrefix(output, "|") {
+		t.Errorf("expected a Markdown table by default, got:\n%v", output)
+	}
+	config.options.outputFormat = "csv"
+	output = captureOutput(func() { commandExport(context.Background(), &config, nil) })
+	if strings.Contains(output, "|") || !strings.Contains(output, ",pikachu,") {
+		t.Errorf("expected CSV from the output_format setting, got:\n%v", output)
+	}
+}
+
+func TestExportAsksBeforeOverwriting(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "team.csv")
+	if err := os.WriteFile(path, []byte("keep me"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	cases := []struct {
+		config  *commandConfig
+		args    []string
+		written bool
+	}{
+		{answering("n"), []string{path}, false},
+		{&commandConfig{}, []string{path}, false},
+		{answering("y"), []string{path}, true},
+		{&commandConfig{}, []string{path, "--force"}, true},
+	}
+	for _, c := range cases {
+		os.WriteFile(path, []byte("keep me"), 0o644)
+		c.config.storage.add(newOwned(1, "pikachu"))
+		captureOutput(func() {
+			if err := commandExport(context.Background(), c.config, c.args); err != nil {
+				t.Errorf("%v: unexpected error: %v", c.args, err)
+			}
+		})
+		data, _ := os.ReadFile(path)
+		if written := string(data) != "keep me"; written != c.written {
+			t.Errorf("%v: expected the file to be overwritten: %v, got %q", c.args, c.written, data)
+		}
+	}
+}
+
+func TestImportUndoForgottenAfterChanges(t *testing.T) {
+	serveAPI(t, map[string]string{
+		"pokemon/pikachu": `{"id": 25, "name": "pikachu", "species": {"name": "pikachu"}}`,
+	})
+	var buf bytes.Buffer
+	encodeRecords(&buf, []exportRecord{{Species: "Pikachu", Storage: "party"}}, "json")
+	path := filepath.Join(t.TempDir(), "collection.json")
+	if err := os.WriteFile(path, buf.Bytes(), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		name   string
+		change func(config *commandConfig)
+		undone bool
+	}{
+		{"nothing", func(config *commandConfig) {}, true},
+		{"seeing a pokemon already seen", func(config *commandConfig) { config.markSeen("pikachu") }, true},
+		{"seeing a new pokemon", func(config *commandConfig) { config.markSeen("pidgey") }, false},
+		{"depositing", func(config *commandConfig) {
+			config.storage.add(newOwned(9, "pidgey"))
+			commandDeposit(context.Background(), config, []string{"pidgey"})
+		}, false},
+	}
+	for _, c := range cases {
+		var config commandConfig
+		captureOutput(func() {
+			commandImport(context.Background(), &config, []string{path})
+			c.change(&config)
+			commandUndo(context.Background(), &config, nil)
+		})
+		_, imported := config.storage.find("pikachu")
+		if imported == c.undone {
+			t.Errorf("%v: expected the import to be undone: %v, got pikachu still owned: %v", c.name, c.undone, imported)
+		}
+	}
+}
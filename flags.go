@@ -0,0 +1,47 @@
+package main
+
+import "strings"
+
+// commandFlags holds the `--name` and `--name=value` options given to a
+// command.
+type commandFlags map[string]string
+
+// parseFlags splits command arguments into positional arguments and flags.
+// Flags named in valueFlags take the following argument as their value when
+// it isn't given with `=`; any other flag without a value is set to "true".
+func parseFlags(args []string, valueFlags ...string) ([]string, commandFlags) {
+	positional := []string{}
+	flags := commandFlags{}
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if !strings.HasPrefix(arg, "--") || arg == "--" {
+			positional = append(positional, arg)
+			continue
+		}
+		name, value, hasValue := strings.Cut(strings.TrimPrefix(arg, "--"), "=")
+		if !hasValue {
+			value = "true"
+			for _, valueFlag := range valueFlags {
+				if name == valueFlag && i+1 < len(args) {
+					i++
+					value = args[i]
+				}
+			}
+		}
+		flags[name] = value
+	}
+	return positional, flags
+}
+
+// has reports whether the boolean flag name was given.
+func (f commandFlags) has(name string) bool {
+	return f[name] == "true"
+}
+
+// get returns the value of flag name, or fallback when it wasn't given.
+func (f commandFlags) get(name, fallback string) string {
+	if value, ok := f[name]; ok {
+		return value
+	}
+	return fallback
+}
@@ -0,0 +1,388 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/zorahscope/pokedexcli/internal/pokeapi"
+)
+
+const defaultLanguage = "en"
+
+func commandInspect(ctx context.Context, config *commandConfig, args []string) error {
+	args, flags := parseFlags(args, "lang", "version-group", "gen", "mode", "width")
+	if len(args) == 0 {
+		fmt.Println("No pokemon selected! Please try again")
+		return nil
+	}
+	loc, ok := config.storage.find(args[0])
+	if !ok {
+		fmt.Println("you have not caught that pokemon")
+		return nil
+	}
+	return inspectOwned(ctx, config, config.storage.get(loc), flags)
+}
+
+// inspectOwned prints the details of an owned pokemon, with the sections the
+// flags ask for.
+func inspectOwned(ctx context.Context, config *commandConfig, owned *ownedPokemon, flags commandFlags) error {
+	pkmn := owned.Pokemon
+	all := flags.has("all")
+	var output strings.Builder
+
+	if all || flags.has("sprite") {
+		img, err := fetchSprite(ctx, pkmn, spriteVariantFlags(flags))
+		if err != nil {
+			fmt.Println(err)
+			return err
+		}
+		sprite, err := drawSprite(img, flags, config.options.spriteMode)
+		if err != nil {
+			fmt.Println(err)
+			return nil
+		}
+		output.WriteString(sprite)
+	}
+
+	if err := config.locale.localizeSpecies(ctx, []pokeapi.Pokemon{pkmn}); err != nil {
+		fmt.Printf("error getting data from API: %v\n", err)
+		return fmt.Errorf("error getting data from API: %w", err)
+	}
+	output.WriteString(fmt.Sprintf("Name: %v\n", config.locale.display(pkmn.Name)))
+	if owned.Nickname != "" {
+		output.WriteString(fmt.Sprintf("Nickname: %v\n", owned.Nickname))
+	}
+	writeSet(&output, *owned)
+	imperial := flags.has("imperial") || config.options.imperial
+	output.WriteString(fmt.Sprintf("Height: %v\n", formatHeight(pkmn.Height, imperial)))
+	output.WriteString(fmt.Sprintf("Weight: %v\n", formatWeight(pkmn.Weight, imperial)))
+	writeStats(&output, pkmn, colorEnabled())
+	if config.version == nil {
+		output.WriteString("Types:\n")
+		for _, typ := range pkmn.Types {
+			output.WriteString(fmt.Sprintf("  - %v\n", typ.Type.Name))
+		}
+	} else {
+		output.WriteString(fmt.Sprintf("Types (%v):\n", config.version.name))
+		for _, typ := range typesIn(pkmn, config.version.generation) {
+			output.WriteString(fmt.Sprintf("  - %v\n", typ))
+		}
+		available, err := availableIn(ctx, pkmn, config.version)
+		if err != nil {
+			fmt.Println(err)
+			return err
+		}
+		if !available {
+			output.WriteString(fmt.Sprintf("Not in the %v pokedex\n", config.version.name))
+		}
+	}
+
+	if all || flags.has("abilities") {
+		writeAbilities(&output, pkmn)
+	}
+	if all || flags.has("items") {
+		writeHeldItems(&output, pkmn, config.versionName())
+	}
+	if all || flags.has("moves") {
+		writeLearnset(&output, pkmn, flags.get("version-group", config.versionGroupName()))
+	}
+	if all || flags.has("flavor") {
+		if err := writeFlavorText(ctx, &output, pkmn, flags.get("lang", config.language())); err != nil {
+			fmt.Printf("error getting data from API: %v\n", err)
+			return err
+		}
+	}
+
+	fmt.Println(output.String())
+
+	return nil
+}
+
+// formatHeight converts a height in decimetres to metres or feet and inches.
+func formatHeight(decimetres int, imperial bool) string {
+	if !imperial {
+		return fmt.Sprintf("%.1f m", float64(decimetres)/10)
+	}
+	inches := int(float64(decimetres)*3.937 + 0.5)
+	return fmt.Sprintf("%d'%d\"", inches/12, inches%12)
+}
+
+// formatWeight converts a weight in hectograms to kilograms or pounds.
+func formatWeight(hectograms int, imperial bool) string {
+	if !imperial {
+		return fmt.Sprintf("%.1f kg", float64(hectograms)/10)
+	}
+	return fmt.Sprintf("%.1f lbs", float64(hectograms)*0.220462)
+}
+
+// inspectBar is how wide a stat bar for the highest base stat is.
+const inspectBar = 24
+
+// colorEnabled reports whether output may be colored, which it is when
+// stdout is a terminal and NO_COLOR isn't set.
+func colorEnabled() bool {
+	if os.Getenv("NO_COLOR") != "" {
+		return false
+	}
+	info, err := os.Stdout.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
+// statColor picks a bar color for a base stat, from red for the lowest to
+// cyan for the highest.
+func statColor(value int) string {
+	switch {
+	case value < 60:
+		return "\x1b[31m"
+	case value < 90:
+		return "\x1b[33m"
+	case value < 120:
+		return "\x1b[32m"
+	}
+	return "\x1b[36m"
+}
+
+// writeStats writes the base stats as bars with their total and EV yield,
+// and the range each stat can have at levels 50 and 100.
+func writeStats(output *strings.Builder, pkmn pokeapi.Pokemon, color bool) {
+	stats := baseStats(pkmn)
+	output.WriteString("Stats:\n")
+	for i, name := range statNames {
+		bar := statBar(stats[i], inspectBar)
+		if color {
+			bar = statColor(stats[i]) + bar + "\x1b[0m"
+		}
+		output.WriteString(fmt.Sprintf("  %-16v %3d %v\n", name, stats[i], bar))
+	}
+	output.WriteString(fmt.Sprintf("  %-16v %3d\n", "total", stats.total()))
+	if yield := evYield(pkmn); yield != "" {
+		output.WriteString(fmt.Sprintf("EV yield: %v\n", yield))
+	}
+
+	levels := []int{50, 100}
+	// Natures that hinder, don't affect and benefit a stat.
+	effects := []int{90, 100, 110}
+	output.WriteString("Stat ranges by nature (-: hindering, =: neutral, +: beneficial):\n")
+	header := fmt.Sprintf("  %-16v", "")
+	for _, level := range levels {
+		for _, mark := range []string{"-", "=", "+"} {
+			header += fmt.Sprintf(" %-9v", fmt.Sprintf("Lv%d %v", level, mark))
+		}
+	}
+	output.WriteString(strings.TrimRight(header, " ") + "\n")
+	for i, name := range statNames {
+		line := fmt.Sprintf("  %-16v", name)
+		for _, level := range levels {
+			for _, percent := range effects {
+				// Natures never affect HP.
+				cell := "-"
+				if name != "hp" || percent == 100 {
+					low, high := statRange(i, stats[i], level, percent)
+					cell = fmt.Sprintf("%d-%d", low, high)
+				}
+				line += fmt.Sprintf(" %-9v", cell)
+			}
+		}
+		output.WriteString(strings.TrimRight(line, " ") + "\n")
+	}
+}
+
+// writeSet writes the competitive details of a pokemon imported from a team,
+// if it has any.
+func writeSet(output *strings.Builder, owned ownedPokemon) {
+	if owned.Item != "" {
+		output.WriteString(fmt.Sprintf("Item: %v\n", owned.Item))
+	}
+	if owned.Ability != "" {
+		output.WriteString(fmt.Sprintf("Ability: %v\n", owned.Ability))
+	}
+	if owned.Nature != "" {
+		output.WriteString(fmt.Sprintf("Nature: %v\n", owned.Nature))
+	}
+	if evs := owned.EVs.format(0); evs != "" {
+		output.WriteString(fmt.Sprintf("EVs: %v\n", evs))
+	}
+	if ivs := owned.ivs().format(maxIV); ivs != "" {
+		output.WriteString(fmt.Sprintf("IVs: %v\n", ivs))
+	}
+	if len(owned.Moves) > 0 {
+		output.WriteString(fmt.Sprintf("Moves: %v\n", strings.Join(owned.Moves, ", ")))
+	}
+}
+
+func writeAbilities(output *strings.Builder, pkmn pokeapi.Pokemon) {
+	output.WriteString("Abilities:\n")
+	for _, ability := range pkmn.Abilities {
+		hidden := ""
+		if ability.IsHidden {
+			hidden = " (hidden)"
+		}
+		output.WriteString(fmt.Sprintf("  - %v%v\n", ability.Ability.Name, hidden))
+	}
+}
+
+// writeHeldItems writes the items a wild pokemon may hold, with their rarity
+// per version. Only version is listed when it's set.
+func writeHeldItems(output *strings.Builder, pkmn pokeapi.Pokemon, version string) {
+	output.WriteString("Held Items:\n")
+	listed := 0
+	for _, item := range pkmn.HeldItems {
+		versions := make([]string, 0, len(item.VersionDetails))
+		for _, detail := range item.VersionDetails {
+			if version != "" && detail.Version.Name != version {
+				continue
+			}
+			versions = append(versions, fmt.Sprintf("%v %d%%", detail.Version.Name, detail.Rarity))
+		}
+		if len(versions) == 0 {
+			continue
+		}
+		listed++
+		output.WriteString(fmt.Sprintf("  - %v: %v\n", item.Item.Name, strings.Join(versions, ", ")))
+	}
+	if listed == 0 {
+		output.WriteString("  - <none>\n")
+	}
+}
+
+// learnedMove is a single entry of a pokemon's learnset.
+type learnedMove struct {
+	name  string
+	level int
+}
+
+// writeLearnset writes the moves learned in versionGroup grouped by learn
+// method. The most recent version group is used when none is given.
+func writeLearnset(output *strings.Builder, pkmn pokeapi.Pokemon, versionGroup string) {
+	if versionGroup == "" {
+		versionGroup = latestVersionGroup(pkmn)
+	}
+	byMethod := make(map[string][]learnedMove)
+	for _, move := range pkmn.Moves {
+		for _, detail := range move.VersionGroupDetails {
+			if detail.VersionGroup.Name != versionGroup {
+				continue
+			}
+			method := detail.MoveLearnMethod.Name
+			byMethod[method] = append(byMethod[method], learnedMove{
+				name:  move.Move.Name,
+				level: detail.LevelLearnedAt,
+			})
+		}
+	}
+
+	output.WriteString(fmt.Sprintf("Moves (%v):\n", versionGroup))
+	if len(byMethod) == 0 {
+		output.WriteString("  - <none>\n")
+		if groups := learnsetVersionGroups(pkmn); len(groups) > 0 {
+			output.WriteString(fmt.Sprintf("  Version groups with moves: %v\n", strings.Join(groups, ", ")))
+		}
+		return
+	}
+	methods := make([]string, 0, len(byMethod))
+	for method := range byMethod {
+		methods = append(methods, method)
+	}
+	sort.Slice(methods, func(i, j int) bool {
+		a, b := learnMethodOrder(methods[i]), learnMethodOrder(methods[j])
+		if a != b {
+			return a < b
+		}
+		return methods[i] < methods[j]
+	})
+	for _, method := range methods {
+		moves := byMethod[method]
+		sort.Slice(moves, func(i, j int) bool {
+			if moves[i].level != moves[j].level {
+				return moves[i].level < moves[j].level
+			}
+			return moves[i].name < moves[j].name
+		})
+		label := method
+		if method == "machine" {
+			label = "machine (TM/HM)"
+		}
+		output.WriteString(fmt.Sprintf("  %v:\n", label))
+		for _, move := range moves {
+			if method == "level-up" {
+				output.WriteString(fmt.Sprintf("    - Lv %2d %v\n", move.level, move.name))
+				continue
+			}
+			output.WriteString(fmt.Sprintf("    - %v\n", move.name))
+		}
+	}
+}
+
+// learnMethods are the common ways of learning a move, in the order learnsets
+// list them. Rarer methods follow in alphabetical order.
+var learnMethods = []string{"level-up", "machine", "egg", "tutor"}
+
+func learnMethodOrder(method string) int {
+	if i := slices.Index(learnMethods, method); i >= 0 {
+		return i
+	}
+	return len(learnMethods)
+}
+
+// learnsetVersionGroups returns the version groups the pokemon has learnset
+// data for, oldest first.
+func learnsetVersionGroups(pkmn pokeapi.Pokemon) []string {
+	ids := make(map[string]int)
+	for _, move := range pkmn.Moves {
+		for _, detail := range move.VersionGroupDetails {
+			ids[detail.VersionGroup.Name] = detail.VersionGroup.ID()
+		}
+	}
+	groups := make([]string, 0, len(ids))
+	for name := range ids {
+		groups = append(groups, name)
+	}
+	sort.Slice(groups, func(i, j int) bool { return ids[groups[i]] < ids[groups[j]] })
+	return groups
+}
+
+// latestVersionGroup returns the version group with the highest id that the
+// pokemon has learnset data for.
+func latestVersionGroup(pkmn pokeapi.Pokemon) string {
+	latest, latestID := "", 0
+	for _, move := range pkmn.Moves {
+		for _, detail := range move.VersionGroupDetails {
+			id := detail.VersionGroup.ID()
+			if id > latestID {
+				latest, latestID = detail.VersionGroup.Name, id
+			}
+		}
+	}
+	return latest
+}
+
+// writeFlavorText writes the genus and most recent pokedex entry for the
+// pokemon's species in the given language.
+func writeFlavorText(ctx context.Context, output *strings.Builder, pkmn pokeapi.Pokemon, language string) error {
+	species, err := pkmn.Species.Resolve(ctx, pokeapi.DefaultClient)
+	if err != nil {
+		return fmt.Errorf("error getting data from API: %w", err)
+	}
+	for _, genus := range species.Genera {
+		if genus.Language.Name == language {
+			output.WriteString(fmt.Sprintf("Genus: %v\n", genus.Genus))
+		}
+	}
+
+	output.WriteString("Pokedex Entry:\n")
+	for i := len(species.FlavorTextEntries) - 1; i >= 0; i-- {
+		entry := species.FlavorTextEntries[i]
+		if entry.Language.Name != language {
+			continue
+		}
+		text := strings.Join(strings.Fields(entry.FlavorText), " ")
+		output.WriteString(fmt.Sprintf("  %v (%v)\n", text, entry.Version.Name))
+		return nil
+	}
+	output.WriteString(fmt.Sprintf("  <no entry in language %q>\n", language))
+	return nil
+}
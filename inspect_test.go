@@ -0,0 +1,93 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"image"
+	"image/color"
+	"image/png"
+	"strings"
+	"testing"
+
+	"github.com/zorahscope/pokedexcli/internal/pokeapi"
+)
+
+func TestFormatHeightAndWeight(t *testing.T) {
+	cases := []struct {
+		value    int
+		imperial bool
+		format   func(int, bool) string
+		expected string
+	}{
+		{7, false, formatHeight, "0.7 m"},
+		{7, true, formatHeight, "2'4\""},
+		{4, true, formatHeight, "1'4\""},
+		{35, true, formatHeight, "11'6\""},
+		{69, false, formatWeight, "6.9 kg"},
+		{69, true, formatWeight, "15.2 lbs"},
+		{9999, true, formatWeight, "2204.4 lbs"},
+	}
+	for _, c := range cases {
+		if got := c.format(c.value, c.imperial); got != c.expected {
+			t.Errorf("expected %v for %d (imperial %v), got %v", c.expected, c.value, c.imperial, got)
+		}
+	}
+}
+
+func TestInspectSections(t *testing.T) {
+	img := image.NewRGBA(image.Rect(0, 0, 2, 2))
+	img.Set(0, 0, color.Black)
+	var sprite bytes.Buffer
+	png.Encode(&sprite, img)
+	serveAPI(t, map[string]string{
+		"sprites/1.png": sprite.String(),
+		"pokemon-species/1": `{"name": "bulbasaur",
+			"genera": [{"genus": "Seed Pokémon", "language": {"name": "en"}}],
+			"flavor_text_entries": [{"flavor_text": "A strange seed was\nplanted on its back.", "language": {"name": "en"}, "version": {"name": "red"}}]}`,
+	})
+	pkmn := pokeapi.Pokemon{Name: "bulbasaur", Height: 7, Weight: 69}
+	pkmn.Species.URL = pokeapi.BaseURL + "pokemon-species/1"
+	pkmn.Sprites.FrontDefault = pokeapi.BaseURL + "sprites/1.png"
+	var config commandConfig
+	config.storage.add(ownedPokemon{ID: 1, Pokemon: pkmn})
+
+	sections := []string{"Abilities:", "Held Items:", "Moves (", "Pokedex Entry:"}
+	cases := []struct {
+		flags    []string
+		expected []string
+	}{
+		{nil, nil},
+		{[]string{"--abilities"}, []string{"Abilities:"}},
+		{[]string{"--items"}, []string{"Held Items:"}},
+		{[]string{"--moves"}, []string{"Moves ("}},
+		{[]string{"--flavor"}, []string{"Pokedex Entry:"}},
+		{[]string{"--abilities", "--moves"}, []string{"Abilities:", "Moves ("}},
+		{[]string{"--all", "--mode", "ascii"}, sections},
+	}
+	for _, c := range cases {
+		output := captureOutput(func() {
+			if err := commandInspect(context.Background(), &config, append([]string{"bulbasaur"}, c.flags...)); err != nil {
+				t.Errorf("unexpected error for %v: %v", c.flags, err)
+			}
+		})
+		if !strings.Contains(output, "Name: bulbasaur\n") || !strings.Contains(output, "Height: 0.7 m\n") {
+			t.Errorf("expected the summary for %v, got:\n%v", c.flags, output)
+		}
+		for _, section := range sections {
+			want := false
+			for _, e := range c.expected {
+				want = want || e == section
+			}
+			if strings.Contains(output, section) != want {
+				t.Errorf("expected %q to be printed with %v: %v, got:\n%v", section, c.flags, want, output)
+			}
+		}
+	}
+
+	output := captureOutput(func() { commandInspect(context.Background(), &config, []string{"bulbasaur", "--imperial", "--flavor"}) })
+	for _, line := range []string{"Height: 2'4\"\n", "Weight: 15.2 lbs\n", "Genus: Seed Pokémon\n", "  A strange seed was planted on its back. (red)\n"} {
+		if !strings.Contains(output, line) {
+			t.Errorf("expected %q, got:\n%v", line, output)
+		}
+	}
+}
@@ -0,0 +1,183 @@
+// Package configfile reads the pokedex configuration file. The file uses a small
+// subset of TOML: `key = value` pairs, `#` comments, and `[profile.<name>]`
+// tables whose settings override the top level ones when that profile is
+// selected. Values may be quoted strings or bare words such as numbers and
+// durations.
+//
+//	cache_ttl = "15m"
+//	units = "metric"
+//
+//	[profile.local]
+//	base_url = "http://localhost:8000/api/v2/"
+//	cache_ttl = "1h"
+package configfile
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// EnvPrefix starts the name of every environment variable that overrides a
+// setting, e.g. POKEDEX_CACHE_TTL for cache_ttl.
+const EnvPrefix = "POKEDEX_"
+
+// File is a parsed configuration file.
+type File struct {
+	// Settings holds the top level settings.
+	Settings map[string]string
+	// Profiles holds the settings of each profile table.
+	Profiles map[string]map[string]string
+}
+
+// Path returns where the configuration file is read from: $POKEDEX_CONFIG
+// when it's set, otherwise pokedexcli/config.toml in the user's config
+// directory ($XDG_CONFIG_HOME or ~/.config on Linux).
+func Path() (string, error) {
+	if path := os.Getenv(EnvPrefix + "CONFIG"); path != "" {
+		return path, nil
+	}
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "pokedexcli", "config.toml"), nil
+}
+
+// Load reads the configuration file at path. A missing file is the same as an
+// empty one.
+func Load(path string) (*File, error) {
+	f, err := os.Open(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return &File{Settings: map[string]string{}, Profiles: map[string]map[string]string{}}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	file, err := Parse(f)
+	if err != nil {
+		return nil, fmt.Errorf("%v: %w", path, err)
+	}
+	return file, nil
+}
+
+// Parse reads a configuration file from r.
+func Parse(r io.Reader) (*File, error) {
+	file := &File{Settings: map[string]string{}, Profiles: map[string]map[string]string{}}
+	table := file.Settings
+	scanner := bufio.NewScanner(r)
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(stripComment(scanner.Text()))
+		if line == "" {
+			continue
+		}
+		if strings.HasPrefix(line, "[") {
+			if !strings.HasSuffix(line, "]") {
+				return nil, fmt.Errorf("line %d: unterminated table header", n)
+			}
+			name, ok := strings.CutPrefix(strings.TrimSpace(line[1:len(line)-1]), "profile.")
+			if !ok || name == "" {
+				return nil, fmt.Errorf("line %d: expected a [profile.<name>] table", n)
+			}
+			if _, ok := file.Profiles[name]; !ok {
+				file.Profiles[name] = map[string]string{}
+			}
+			table = file.Profiles[name]
+			continue
+		}
+		key, value, ok := strings.Cut(line, "=")
+		key = strings.TrimSpace(key)
+		if !ok || key == "" {
+			return nil, fmt.Errorf("line %d: expected key = value", n)
+		}
+		value, err := parseValue(strings.TrimSpace(value))
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", n, err)
+		}
+		table[key] = value
+	}
+	return file, scanner.Err()
+}
+
+// stripComment removes a # comment that isn't inside a quoted string.
+func stripComment(line string) string {
+	quoted := false
+	for i := 0; i < len(line); i++ {
+		switch line[i] {
+		case '\\':
+			if quoted {
+				i++
+			}
+		case '"':
+			quoted = !quoted
+		case '#':
+			if !quoted {
+				return line[:i]
+			}
+		}
+	}
+	return line
+}
+
+func parseValue(value string) (string, error) {
+	if value == "" {
+		return "", fmt.Errorf("missing value")
+	}
+	if strings.HasPrefix(value, `"`) {
+		unquoted, err := strconv.Unquote(value)
+		if err != nil {
+			return "", fmt.Errorf("malformed string %v", value)
+		}
+		return unquoted, nil
+	}
+	if strings.HasPrefix(value, "'") {
+		if len(value) < 2 || !strings.HasSuffix(value, "'") {
+			return "", fmt.Errorf("malformed string %v", value)
+		}
+		return value[1 : len(value)-1], nil
+	}
+	return value, nil
+}
+
+// Resolve returns the settings for profile: the top level settings, then the
+// profile's, then environment variables, each overriding the last. An empty
+// profile uses the top level settings alone.
+func (f *File) Resolve(profile string, keys []string) (map[string]string, error) {
+	settings := make(map[string]string, len(f.Settings))
+	for key, value := range f.Settings {
+		settings[key] = value
+	}
+	if profile != "" {
+		overrides, ok := f.Profiles[profile]
+		if !ok {
+			return nil, fmt.Errorf("no profile named %q, expected one of %v", profile, strings.Join(f.ProfileNames(), ", "))
+		}
+		for key, value := range overrides {
+			settings[key] = value
+		}
+	}
+	for _, key := range keys {
+		if value, ok := os.LookupEnv(EnvPrefix + strings.ToUpper(key)); ok {
+			settings[key] = value
+		}
+	}
+	return settings, nil
+}
+
+// ProfileNames returns the names of the profiles in the file, sorted.
+func (f *File) ProfileNames() []string {
+	names := make([]string, 0, len(f.Profiles))
+	for name := range f.Profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,64 @@
+package configfile
+
+import (
+	"strings"
+	"testing"
+)
+
+const testConfig = `
+# defaults
+cache_ttl = "15m"
+units = metric
+page_size = 20 # trailing comment
+
+[profile.local]
+base_url = "http://localhost:8000/api/v2/#not-a-comment"
+cache_ttl = '1h'
+`
+
+func TestParse(t *testing.T) {
+	file, err := Parse(strings.NewReader(testConfig))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if file.Settings["cache_ttl"] != "15m" || file.Settings["units"] != "metric" || file.Settings["page_size"] != "20" {
+		t.Errorf("unexpected top level settings %v", file.Settings)
+	}
+	local := file.Profiles["local"]
+	if local["base_url"] != "http://localhost:8000/api/v2/#not-a-comment" || local["cache_ttl"] != "1h" {
+		t.Errorf("unexpected profile settings %v", local)
+	}
+
+	bad := []string{
+		"cache_ttl",
+		"cache_ttl =",
+		`base_url = "unterminated`,
+		"[local]",
+		"[profile.local",
+	}
+	for _, input := range bad {
+		if _, err := Parse(strings.NewReader(input)); err == nil {
+			t.Errorf("expected an error parsing %q", input)
+		}
+	}
+}
+
+func TestResolve(t *testing.T) {
+	file, err := Parse(strings.NewReader(testConfig))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	t.Setenv("POKEDEX_UNITS", "imperial")
+	keys := []string{"base_url", "cache_ttl", "units", "page_size"}
+
+	settings, err := file.Resolve("local", keys)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if settings["cache_ttl"] != "1h" || settings["page_size"] != "20" || settings["units"] != "imperial" {
+		t.Errorf("expected the profile and environment to override the defaults, got %v", settings)
+	}
+	if _, err := file.Resolve("missing", keys); err == nil {
+		t.Error("expected an error for an unknown profile")
+	}
+}
@@ -1,20 +1,84 @@
 package pokeapi
 
+//go:generate go run ./gen
+
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"github.com/zorahscope/pokedexcli/internal/pokecache"
 	"io"
 	"net/http"
+	"sync"
 	"time"
 )
 
-var cache = pokecache.NewCache(time.Minute * 15)
+// DefaultBaseURL is the root of the public PokeAPI v2 endpoints.
+const DefaultBaseURL = "https://pokeapi.co/api/v2/"
+
+// BaseURL is the root every endpoint URL is built from. It can be pointed at
+// a mirror or a local instance of the API.
+var BaseURL = DefaultBaseURL
+
+// ErrNotFound is returned, wrapped, when the API has no resource at a URL.
+var ErrNotFound = errors.New("not found")
+
+// requestTimeout bounds how long a single request may take, including
+// reading the response body.
+const requestTimeout = 30 * time.Second
+
+// Client fetches resources from the API, caching each response so repeated
+// lookups of the same URL don't hit the network. Concurrent lookups of a URL
+// that isn't cached yet share a single request.
+type Client struct {
+	// mu guards httpClient, which SetTimeout swaps while requests, such as
+	// page prefetches, may be running.
+	mu         sync.Mutex
+	httpClient *http.Client
+	cache      *pokecache.Cache
+	flights    flightGroup
+	workers    int
+}
+
+// NewClient returns a client whose cached responses expire after
+// cacheInterval.
+func NewClient(cacheInterval time.Duration) *Client {
+	return &Client{
+		httpClient: &http.Client{Timeout: requestTimeout},
+		cache:      pokecache.NewCache(cacheInterval),
+		workers:    defaultWorkers,
+	}
+}
+
+// SetCacheTTL changes how long cached responses are kept, including the ones
+// already cached.
+func (c *Client) SetCacheTTL(ttl time.Duration) {
+	c.cache.SetInterval(ttl)
+}
 
-func GetFromAPI[T apiResponse](url string) (T, error) {
+// SetTimeout changes how long a single request may take. Requests already
+// running keep the timeout they started with.
+func (c *Client) SetTimeout(timeout time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.httpClient = &http.Client{Timeout: timeout}
+}
+
+func (c *Client) currentHTTPClient() *http.Client {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.httpClient
+}
+
+// DefaultClient is used by the package level helpers such as GetFromAPI.
+var DefaultClient = NewClient(time.Minute * 15)
+
+// Get fetches url with client c and decodes the response into T.
+func Get[T apiResponse](ctx context.Context, c *Client, url string) (T, error) {
 	var result T
 
-	data, err := getRawData(url)
+	data, err := c.getRawData(ctx, url)
 	if err != nil {
 		return result, err
 	}
@@ -24,27 +88,51 @@ func GetFromAPI[T apiResponse](url string) (T, error) {
 	return result, nil
 }
 
-func getRawData(url string) ([]byte, error) {
+func GetFromAPI[T apiResponse](ctx context.Context, url string) (T, error) {
+	return Get[T](ctx, DefaultClient, url)
+}
+
+// GetRaw fetches url through the cache without decoding it, for resources
+// such as sprite images that aren't JSON.
+func GetRaw(ctx context.Context, url string) ([]byte, error) {
+	return DefaultClient.getRawData(ctx, url)
+}
+
+func (c *Client) getRawData(ctx context.Context, url string) ([]byte, error) {
 	var cachedData []byte
 	var ok bool
 
-	cachedData, ok = cache.Get(url)
+	cachedData, ok = c.cache.Get(url)
 	if ok {
 		return cachedData, nil
 	}
-	res, err := http.Get(url)
+	return c.flights.do(ctx, url, func(ctx context.Context) ([]byte, error) {
+		return c.fetch(ctx, url)
+	})
+}
+
+// fetch requests url from the network and caches the response.
+func (c *Client) fetch(ctx context.Context, url string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return []byte{}, fmt.Errorf("error creating http request: %v", err)
+	}
+	res, err := c.currentHTTPClient().Do(req)
 	if err != nil {
 		return []byte{}, fmt.Errorf("error making http request: %v", err)
 	}
+	defer res.Body.Close()
+	if res.StatusCode == http.StatusNotFound {
+		return []byte{}, fmt.Errorf("%v %w", res.StatusCode, ErrNotFound)
+	}
 	if res.StatusCode >= 400 {
 		return []byte{}, fmt.Errorf("%v not found", res.StatusCode)
 	}
-	defer res.Body.Close()
 
 	data, err := io.ReadAll(res.Body)
 	if err != nil {
 		return []byte{}, fmt.Errorf("error reading response body: %v", err)
 	}
-	cache.Add(url, data)
+	c.cache.Add(url, data)
 	return data, nil
 }
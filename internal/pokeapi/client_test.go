@@ -0,0 +1,57 @@
+package pokeapi
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestSetCacheTTLKeepsEntries(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		fmt.Fprint(w, `{"id": 25, "name": "pikachu"}`)
+	}))
+	defer server.Close()
+
+	client := NewClient(time.Minute)
+	url := server.URL + "/pokemon/pikachu/"
+	if _, err := Get[Pokemon](context.Background(), client, url); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	client.SetCacheTTL(time.Hour)
+	client.SetTimeout(time.Second)
+	if _, err := Get[Pokemon](context.Background(), client, url); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if n := requests.Load(); n != 1 {
+		t.Errorf("expected the cached response to be kept, got %d requests", n)
+	}
+}
+
+// TestClientSettingsWhileFetching changes the settings while requests are
+// running, for the race detector to check.
+func TestClientSettingsWhileFetching(t *testing.T) {
+	server := newListServer(100)
+	defer server.Close()
+
+	client := NewClient(time.Minute)
+	var wg sync.WaitGroup
+	for i := range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			Get[NamedResourceList](context.Background(), client, fmt.Sprintf("%v/pokemon/?offset=%d&limit=10", server.URL, i*10))
+		}()
+	}
+	for i := range 10 {
+		client.SetTimeout(time.Duration(i+1) * time.Second)
+		client.SetCacheTTL(time.Duration(i+1) * time.Minute)
+	}
+	wg.Wait()
+}
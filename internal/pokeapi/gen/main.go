@@ -0,0 +1,108 @@
+// Command gen writes resources_gen.go, which declares the apiResponse
+// constraint and a typed accessor for every PokeAPI v2 resource endpoint.
+// Add new endpoints to the resources table and run `go generate` in the
+// pokeapi package.
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"log"
+	"os"
+)
+
+type resource struct {
+	endpoint string
+	model    string
+	// byID is set for resources that can only be looked up by id.
+	byID bool
+}
+
+var resources = []resource{
+	{endpoint: "ability", model: "Ability"},
+	{endpoint: "berry", model: "Berry"},
+	{endpoint: "berry-firmness", model: "BerryFirmness"},
+	{endpoint: "berry-flavor", model: "BerryFlavor"},
+	{endpoint: "characteristic", model: "Characteristic", byID: true},
+	{endpoint: "contest-effect", model: "ContestEffect", byID: true},
+	{endpoint: "contest-type", model: "ContestType"},
+	{endpoint: "egg-group", model: "EggGroup"},
+	{endpoint: "encounter-condition", model: "EncounterCondition"},
+	{endpoint: "encounter-condition-value", model: "EncounterConditionValue"},
+	{endpoint: "encounter-method", model: "EncounterMethod"},
+	{endpoint: "evolution-chain", model: "EvolutionChain", byID: true},
+	{endpoint: "evolution-trigger", model: "EvolutionTrigger"},
+	{endpoint: "gender", model: "Gender"},
+	{endpoint: "generation", model: "Generation"},
+	{endpoint: "growth-rate", model: "GrowthRate"},
+	{endpoint: "item", model: "Item"},
+	{endpoint: "item-attribute", model: "ItemAttribute"},
+	{endpoint: "item-category", model: "ItemCategory"},
+	{endpoint: "item-fling-effect", model: "ItemFlingEffect"},
+	{endpoint: "item-pocket", model: "ItemPocket"},
+	{endpoint: "language", model: "Language"},
+	{endpoint: "location", model: "Location"},
+	{endpoint: "location-area", model: "LocationArea"},
+	{endpoint: "machine", model: "Machine", byID: true},
+	{endpoint: "move", model: "Move"},
+	{endpoint: "move-ailment", model: "MoveAilment"},
+	{endpoint: "move-battle-style", model: "MoveBattleStyle"},
+	{endpoint: "move-category", model: "MoveCategory"},
+	{endpoint: "move-damage-class", model: "MoveDamageClass"},
+	{endpoint: "move-learn-method", model: "MoveLearnMethod"},
+	{endpoint: "move-target", model: "MoveTarget"},
+	{endpoint: "nature", model: "Nature"},
+	{endpoint: "pal-park-area", model: "PalParkArea"},
+	{endpoint: "pokeathlon-stat", model: "PokeathlonStat"},
+	{endpoint: "pokedex", model: "Pokedex"},
+	{endpoint: "pokemon", model: "Pokemon"},
+	{endpoint: "pokemon-color", model: "PokemonColor"},
+	{endpoint: "pokemon-form", model: "PokemonForm"},
+	{endpoint: "pokemon-habitat", model: "PokemonHabitat"},
+	{endpoint: "pokemon-shape", model: "PokemonShape"},
+	{endpoint: "pokemon-species", model: "PokemonSpecies"},
+	{endpoint: "region", model: "Region"},
+	{endpoint: "stat", model: "Stat"},
+	{endpoint: "super-contest-effect", model: "SuperContestEffect", byID: true},
+	{endpoint: "type", model: "Type"},
+	{endpoint: "version", model: "Version"},
+	{endpoint: "version-group", model: "VersionGroup"},
+}
+
+// responses are decoded by GetFromAPI but aren't resources of their own.
+var responses = []string{"LocationAreaList", "NamedResourceList", "[]LocationAreaEncounter"}
+
+func main() {
+	var buf bytes.Buffer
+	buf.WriteString("// Code generated by go run ./gen; DO NOT EDIT.\n\npackage pokeapi\n\n")
+	buf.WriteString("import (\n\t\"context\"\n\t\"strconv\"\n)\n\n")
+	buf.WriteString("// apiResponse lists every type GetFromAPI can decode.\ntype apiResponse interface {\n")
+	for _, r := range responses {
+		fmt.Fprintf(&buf, "\t%v |\n", r)
+	}
+	for i, r := range resources {
+		sep := " |"
+		if i == len(resources)-1 {
+			sep = ""
+		}
+		fmt.Fprintf(&buf, "\t%v%v\n", r.model, sep)
+	}
+	buf.WriteString("}\n")
+
+	for _, r := range resources {
+		if r.byID {
+			fmt.Fprintf(&buf, "\n// Get%[1]v fetches /%[2]v/{id}.\nfunc Get%[1]v(ctx context.Context, id int) (%[1]v, error) {\n\treturn GetFromAPI[%[1]v](ctx, BaseURL + \"%[2]v/\" + strconv.Itoa(id))\n}\n", r.model, r.endpoint)
+			continue
+		}
+		fmt.Fprintf(&buf, "\n// Get%[1]v fetches /%[2]v/{name or id}.\nfunc Get%[1]v(ctx context.Context, nameOrID string) (%[1]v, error) {\n\treturn GetFromAPI[%[1]v](ctx, BaseURL + \"%[2]v/\" + nameOrID)\n}\n", r.model, r.endpoint)
+	}
+
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		log.Fatalf("formatting generated code: %v", err)
+	}
+	if err := os.WriteFile("resources_gen.go", src, 0o644); err != nil {
+		log.Fatal(err)
+	}
+}
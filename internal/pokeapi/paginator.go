@@ -0,0 +1,110 @@
+package pokeapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// DefaultPageSize is the page size the API uses when none is requested.
+const DefaultPageSize = 20
+
+// Paging errors are returned when the page asked for doesn't exist. They
+// aren't API errors, so can be shown to the user as they are.
+var (
+	ErrFirstPage = errors.New("you're on the first page")
+	ErrLastPage  = errors.New("you're on the last page")
+	ErrNoPage    = errors.New("that page doesn't exist")
+)
+
+// Paginator walks the pages of a named resource list endpoint such as
+// /location-area or /pokemon. Pages are numbered from 1. After a page is
+// fetched the following page is requested in the background so it's usually
+// already cached when asked for.
+type Paginator struct {
+	client *Client
+	url    string
+	limit  int
+	page   int
+	count  int
+}
+
+// NewPaginator returns a paginator that fetches the list endpoint at url,
+// for example BaseURL + "location-area/", with client c. A limit below 1
+// uses DefaultPageSize.
+func NewPaginator(c *Client, url string, limit int) *Paginator {
+	if limit < 1 {
+		limit = DefaultPageSize
+	}
+	return &Paginator{client: c, url: url, limit: limit, count: -1}
+}
+
+// Limit returns the number of results per page.
+func (p *Paginator) Limit() int {
+	return p.limit
+}
+
+// Current returns the number of the last page fetched, or 0 before the first
+// fetch.
+func (p *Paginator) Current() int {
+	return p.page
+}
+
+// Pages returns the total number of pages, or 0 before the first fetch.
+func (p *Paginator) Pages() int {
+	if p.count < 0 {
+		return 0
+	}
+	return (p.count + p.limit - 1) / p.limit
+}
+
+// Next fetches the page after the current one.
+func (p *Paginator) Next(ctx context.Context) ([]Result, error) {
+	if p.count >= 0 && p.page >= p.Pages() {
+		return nil, ErrLastPage
+	}
+	return p.Page(ctx, p.page+1)
+}
+
+// Previous fetches the page before the current one.
+func (p *Paginator) Previous(ctx context.Context) ([]Result, error) {
+	if p.page <= 1 {
+		return nil, ErrFirstPage
+	}
+	return p.Page(ctx, p.page-1)
+}
+
+// Page fetches page n and makes it the current page. Before the number of
+// pages is known a page past the end is only found out once it's fetched,
+// in which case the current page is left as it was.
+func (p *Paginator) Page(ctx context.Context, n int) ([]Result, error) {
+	if n < 1 || (p.count >= 0 && n > max(1, p.Pages())) {
+		return nil, p.noPage()
+	}
+	list, err := Get[NamedResourceList](ctx, p.client, p.pageURL(n))
+	if err != nil {
+		return nil, err
+	}
+	p.count = int(list.Count)
+	if n > max(1, p.Pages()) {
+		return nil, p.noPage()
+	}
+	p.page = n
+	if n < p.Pages() {
+		// The prefetch outlives the request that triggered it, so it isn't
+		// tied to ctx.
+		go p.client.getRawData(context.Background(), p.pageURL(n+1))
+	}
+	return list.Results, nil
+}
+
+func (p *Paginator) noPage() error {
+	if p.count < 0 {
+		return ErrNoPage
+	}
+	return fmt.Errorf("%w, there are %d pages", ErrNoPage, p.Pages())
+}
+
+func (p *Paginator) pageURL(n int) string {
+	return fmt.Sprintf("%v?offset=%d&limit=%d", p.url, (n-1)*p.limit, p.limit)
+}
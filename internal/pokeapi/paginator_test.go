@@ -0,0 +1,83 @@
+package pokeapi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+)
+
+// newListServer serves a list endpoint with count results named area-0,
+// area-1 and so on.
+func newListServer(count int) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+		limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+		list := NamedResourceList{Count: int64(count)}
+		for i := offset; i < min(offset+limit, count); i++ {
+			list.Results = append(list.Results, Result{Name: fmt.Sprintf("area-%d", i)})
+		}
+		json.NewEncoder(w).Encode(list)
+	}))
+}
+
+func TestPaginator(t *testing.T) {
+	server := newListServer(45)
+	defer server.Close()
+	p := NewPaginator(NewClient(time.Minute), server.URL+"/location-area/", 20)
+
+	if _, err := p.Previous(context.Background()); err != ErrFirstPage {
+		t.Errorf("expected ErrFirstPage before the first fetch, got %v", err)
+	}
+	results, err := p.Next(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(results) != 20 || results[0].Name != "area-0" || p.Pages() != 3 {
+		t.Errorf("unexpected first page %v of %d pages", results, p.Pages())
+	}
+
+	results, err = p.Page(context.Background(), 3)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(results) != 5 || results[0].Name != "area-40" {
+		t.Errorf("unexpected last page %v", results)
+	}
+	if _, err := p.Next(context.Background()); err != ErrLastPage {
+		t.Errorf("expected ErrLastPage, got %v", err)
+	}
+
+	results, err = p.Previous(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if p.Current() != 2 || results[0].Name != "area-20" {
+		t.Errorf("expected page 2 starting at area-20, got page %d %v", p.Current(), results)
+	}
+	if _, err := p.Page(context.Background(), 4); !errors.Is(err, ErrNoPage) {
+		t.Errorf("expected ErrNoPage for a page past the end, got %v", err)
+	}
+}
+
+func TestPaginatorPagePastEndBeforeCount(t *testing.T) {
+	server := newListServer(45)
+	defer server.Close()
+	p := NewPaginator(NewClient(time.Minute), server.URL+"/location-area/", 20)
+
+	if _, err := p.Page(context.Background(), 1000); !errors.Is(err, ErrNoPage) {
+		t.Fatalf("expected ErrNoPage, got %v", err)
+	}
+	if p.Current() != 0 || p.Pages() != 3 {
+		t.Errorf("expected to stay before the first page with 3 pages known, got page %d of %d", p.Current(), p.Pages())
+	}
+	results, err := p.Next(context.Background())
+	if err != nil || p.Current() != 1 || results[0].Name != "area-0" {
+		t.Errorf("expected the first page next, got page %d %v, %v", p.Current(), results, err)
+	}
+}
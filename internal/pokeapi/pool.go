@@ -0,0 +1,125 @@
+package pokeapi
+
+import (
+	"context"
+	"sync"
+)
+
+// defaultWorkers is how many requests GetAll makes at once.
+const defaultWorkers = 8
+
+// flight is a request for a URL that other callers can wait on.
+type flight struct {
+	done chan struct{}
+	data []byte
+	err  error
+}
+
+// flightGroup coalesces concurrent requests for the same URL so the network is
+// only hit once, with every caller sharing the result.
+type flightGroup struct {
+	mu      sync.Mutex
+	flights map[string]*flight
+}
+
+// do calls fetch for url unless a fetch for it is already in progress, in
+// which case it waits for that one instead. The fetch isn't tied to any one
+// caller's context, so a caller giving up doesn't fail the others; it's
+// still bounded by the client's request timeout.
+func (g *flightGroup) do(ctx context.Context, url string, fetch func(ctx context.Context) ([]byte, error)) ([]byte, error) {
+	g.mu.Lock()
+	if g.flights == nil {
+		g.flights = make(map[string]*flight)
+	}
+	f, ok := g.flights[url]
+	if !ok {
+		f = &flight{done: make(chan struct{})}
+		g.flights[url] = f
+		go func() {
+			f.data, f.err = fetch(context.WithoutCancel(ctx))
+			g.mu.Lock()
+			delete(g.flights, url)
+			g.mu.Unlock()
+			close(f.done)
+		}()
+	}
+	g.mu.Unlock()
+
+	select {
+	case <-f.done:
+		return f.data, f.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// GetAll fetches every url with client c using a bounded pool of workers and
+// returns the results in the same order as urls. The first error cancels the
+// remaining requests.
+func GetAll[T apiResponse](ctx context.Context, c *Client, urls []string) ([]T, error) {
+	results, errs := getAll[T](ctx, c, urls, true)
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// GetEach is like GetAll but carries on past failed requests, returning the
+// error of each request alongside the results.
+func GetEach[T apiResponse](ctx context.Context, c *Client, urls []string) ([]T, []error) {
+	return getAll[T](ctx, c, urls, false)
+}
+
+// getAll fetches urls with c.workers workers. When stopOnError is set the
+// first error cancels the requests that haven't started, and only that error
+// is reported.
+func getAll[T apiResponse](ctx context.Context, c *Client, urls []string, stopOnError bool) ([]T, []error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	results := make([]T, len(urls))
+	errs := make([]error, len(urls))
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	var once sync.Once
+
+	for range min(c.workers, len(urls)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				result, err := Get[T](ctx, c, urls[i])
+				if err != nil && stopOnError {
+					once.Do(func() {
+						errs[i] = err
+						cancel()
+					})
+					continue
+				}
+				results[i], errs[i] = result, err
+			}
+		}()
+	}
+
+feed:
+	for i := range urls {
+		select {
+		case indexes <- i:
+		case <-ctx.Done():
+			if !stopOnError {
+				for j := i; j < len(urls); j++ {
+					errs[j] = ctx.Err()
+				}
+			}
+			break feed
+		}
+	}
+	close(indexes)
+	wg.Wait()
+	return results, errs
+}
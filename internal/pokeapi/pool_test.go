@@ -0,0 +1,111 @@
+package pokeapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestGetCoalescesRequests(t *testing.T) {
+	var requests atomic.Int32
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		<-release
+		fmt.Fprint(w, `{"id": 1, "name": "bulbasaur"}`)
+	}))
+	defer server.Close()
+
+	client := NewClient(time.Minute)
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			pkmn, err := Get[Pokemon](context.Background(), client, server.URL+"/pokemon/1/")
+			if err != nil || pkmn.Name != "bulbasaur" {
+				t.Errorf("unexpected result %+v, %v", pkmn, err)
+			}
+		}()
+	}
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	if n := requests.Load(); n != 1 {
+		t.Errorf("expected concurrent lookups to share 1 request, got %d", n)
+	}
+}
+
+func TestGetAll(t *testing.T) {
+	var inFlight, peak atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := inFlight.Add(1)
+		defer inFlight.Add(-1)
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+		if strings.HasSuffix(r.URL.Path, "/missing/") {
+			http.NotFound(w, r)
+			return
+		}
+		name := strings.Trim(strings.TrimPrefix(r.URL.Path, "/pokemon/"), "/")
+		fmt.Fprintf(w, `{"name": %q}`, name)
+	}))
+	defer server.Close()
+
+	client := NewClient(time.Minute)
+	client.workers = 3
+	urls := make([]string, 10)
+	for i := range urls {
+		urls[i] = fmt.Sprintf("%v/pokemon/p%d/", server.URL, i)
+	}
+	results, err := GetAll[Pokemon](context.Background(), client, urls)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for i, pkmn := range results {
+		if pkmn.Name != fmt.Sprintf("p%d", i) {
+			t.Errorf("expected result %d to be p%d, got %v", i, i, pkmn.Name)
+		}
+	}
+	if p := peak.Load(); p > 3 {
+		t.Errorf("expected at most 3 requests at once, got %d", p)
+	}
+
+	urls = append(urls, server.URL+"/pokemon/missing/")
+	if _, err := GetAll[Pokemon](context.Background(), client, urls); err == nil {
+		t.Error("expected an error when one of the requests fails")
+	}
+}
+
+func TestGetEach(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/missing/") {
+			http.NotFound(w, r)
+			return
+		}
+		fmt.Fprint(w, `{"name": "pikachu"}`)
+	}))
+	defer server.Close()
+
+	urls := []string{server.URL + "/pokemon/pikachu/", server.URL + "/pokemon/missing/"}
+	results, errs := GetEach[Pokemon](context.Background(), NewClient(time.Minute), urls)
+	if errs[0] != nil || results[0].Name != "pikachu" {
+		t.Errorf("expected pikachu, got %+v, %v", results[0], errs[0])
+	}
+	if !errors.Is(errs[1], ErrNotFound) {
+		t.Errorf("expected ErrNotFound for the missing pokemon, got %v", errs[1])
+	}
+}
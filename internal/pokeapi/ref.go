@@ -0,0 +1,23 @@
+package pokeapi
+
+import "context"
+
+// Ref is a reference to another resource of type T, as found in the
+// {"name", "url"} stubs throughout the API. Resolve follows it without any
+// URL building.
+type Ref[T apiResponse] struct {
+	Name string `json:"name"`
+	URL  string `json:"url"`
+}
+
+// Resolve fetches the referenced resource with client c. Responses are
+// cached by the client, so resolving the same reference again is cheap.
+func (r Ref[T]) Resolve(ctx context.Context, c *Client) (T, error) {
+	return Get[T](ctx, c, r.URL)
+}
+
+// ID returns the numeric id at the end of the reference URL, or 0 when the
+// URL doesn't end in one.
+func (r Ref[T]) ID() int {
+	return Result{Name: r.Name, URL: r.URL}.ID()
+}
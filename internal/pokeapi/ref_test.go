@@ -0,0 +1,37 @@
+package pokeapi
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestRefResolve(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		fmt.Fprint(w, `{"id": 25, "name": "pikachu", "generation": {"name": "generation-i", "url": "https://pokeapi.co/api/v2/generation/1/"}}`)
+	}))
+	defer server.Close()
+
+	client := NewClient(time.Minute)
+	ref := Ref[PokemonSpecies]{Name: "pikachu", URL: server.URL + "/pokemon-species/25/"}
+	for i := 0; i < 2; i++ {
+		species, err := ref.Resolve(context.Background(), client)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if species.Name != "pikachu" || species.Generation.ID() != 1 {
+			t.Errorf("unexpected species %+v", species)
+		}
+	}
+	if requests != 1 {
+		t.Errorf("expected the second resolve to be cached, got %d requests", requests)
+	}
+	if ref.ID() != 25 {
+		t.Errorf("expected id 25, got %d", ref.ID())
+	}
+}
@@ -0,0 +1,653 @@
+package pokeapi
+
+// The models in this file follow the PokeAPI v2 documentation at
+// https://pokeapi.co/docs/v2. Fields referencing named resources that are
+// followed, such as an encounter's version or a move's type, use Ref so they
+// can be resolved to the resource they point at. Other named references,
+// mostly languages and the like that are only ever read for their name, use
+// Result, and unnamed ones use APIResource.
+
+// APIResource references a resource that has no name, such as an evolution
+// chain or machine.
+type APIResource struct {
+	URL string `json:"url"`
+}
+
+type Name struct {
+	Name     string `json:"name"`
+	Language Result `json:"language"`
+}
+
+type Description struct {
+	Description string `json:"description"`
+	Language    Result `json:"language"`
+}
+
+type Effect struct {
+	Effect   string `json:"effect"`
+	Language Result `json:"language"`
+}
+
+type VerboseEffect struct {
+	Effect      string `json:"effect"`
+	ShortEffect string `json:"short_effect"`
+	Language    Result `json:"language"`
+}
+
+type FlavorText struct {
+	FlavorText string `json:"flavor_text"`
+	Language   Result `json:"language"`
+	Version    Result `json:"version"`
+}
+
+type VersionGroupFlavorText struct {
+	Text         string `json:"text"`
+	Language     Result `json:"language"`
+	VersionGroup Result `json:"version_group"`
+}
+
+type GenerationGameIndex struct {
+	GameIndex  int    `json:"game_index"`
+	Generation Result `json:"generation"`
+}
+
+type VersionGameIndex struct {
+	GameIndex int    `json:"game_index"`
+	Version   Result `json:"version"`
+}
+
+type MachineVersionDetail struct {
+	Machine      APIResource `json:"machine"`
+	VersionGroup Result      `json:"version_group"`
+}
+
+type Encounter struct {
+	MinLevel        int                  `json:"min_level"`
+	MaxLevel        int                  `json:"max_level"`
+	ConditionValues []Result             `json:"condition_values"`
+	Chance          int                  `json:"chance"`
+	Method          Ref[EncounterMethod] `json:"method"`
+}
+
+type VersionEncounterDetail struct {
+	Version          Ref[Version] `json:"version"`
+	MaxChance        int          `json:"max_chance"`
+	EncounterDetails []Encounter  `json:"encounter_details"`
+}
+
+// Berries
+
+type Berry struct {
+	ID               int    `json:"id"`
+	Name             string `json:"name"`
+	GrowthTime       int    `json:"growth_time"`
+	MaxHarvest       int    `json:"max_harvest"`
+	NaturalGiftPower int    `json:"natural_gift_power"`
+	Size             int    `json:"size"`
+	Smoothness       int    `json:"smoothness"`
+	SoilDryness      int    `json:"soil_dryness"`
+	Firmness         Result `json:"firmness"`
+	Flavors          []struct {
+		Potency int    `json:"potency"`
+		Flavor  Result `json:"flavor"`
+	} `json:"flavors"`
+	Item            Result `json:"item"`
+	NaturalGiftType Result `json:"natural_gift_type"`
+}
+
+type BerryFirmness struct {
+	ID      int      `json:"id"`
+	Name    string   `json:"name"`
+	Berries []Result `json:"berries"`
+	Names   []Name   `json:"names"`
+}
+
+type BerryFlavor struct {
+	ID      int    `json:"id"`
+	Name    string `json:"name"`
+	Berries []struct {
+		Potency int    `json:"potency"`
+		Berry   Result `json:"berry"`
+	} `json:"berries"`
+	ContestType Result `json:"contest_type"`
+	Names       []Name `json:"names"`
+}
+
+// Contests
+
+type ContestType struct {
+	ID          int    `json:"id"`
+	Name        string `json:"name"`
+	BerryFlavor Result `json:"berry_flavor"`
+	Names       []struct {
+		Name     string `json:"name"`
+		Color    string `json:"color"`
+		Language Result `json:"language"`
+	} `json:"names"`
+}
+
+type ContestEffect struct {
+	ID                int          `json:"id"`
+	Appeal            int          `json:"appeal"`
+	Jam               int          `json:"jam"`
+	EffectEntries     []Effect     `json:"effect_entries"`
+	FlavorTextEntries []FlavorText `json:"flavor_text_entries"`
+}
+
+type SuperContestEffect struct {
+	ID                int          `json:"id"`
+	Appeal            int          `json:"appeal"`
+	FlavorTextEntries []FlavorText `json:"flavor_text_entries"`
+	Moves             []Result     `json:"moves"`
+}
+
+// Encounters
+
+type EncounterMethod struct {
+	ID    int    `json:"id"`
+	Name  string `json:"name"`
+	Order int    `json:"order"`
+	Names []Name `json:"names"`
+}
+
+type EncounterCondition struct {
+	ID     int      `json:"id"`
+	Name   string   `json:"name"`
+	Names  []Name   `json:"names"`
+	Values []Result `json:"values"`
+}
+
+type EncounterConditionValue struct {
+	ID        int    `json:"id"`
+	Name      string `json:"name"`
+	Condition Result `json:"condition"`
+	Names     []Name `json:"names"`
+}
+
+// Evolution
+
+// ChainLink is one species in an evolution chain along with the species it
+// evolves into.
+type ChainLink struct {
+	IsBaby           bool   `json:"is_baby"`
+	Species          Result `json:"species"`
+	EvolutionDetails []struct {
+		Item                  *Result `json:"item"`
+		Trigger               Result  `json:"trigger"`
+		Gender                *int    `json:"gender"`
+		HeldItem              *Result `json:"held_item"`
+		KnownMove             *Result `json:"known_move"`
+		KnownMoveType         *Result `json:"known_move_type"`
+		Location              *Result `json:"location"`
+		MinLevel              *int    `json:"min_level"`
+		MinHappiness          *int    `json:"min_happiness"`
+		MinBeauty             *int    `json:"min_beauty"`
+		MinAffection          *int    `json:"min_affection"`
+		NeedsOverworldRain    bool    `json:"needs_overworld_rain"`
+		PartySpecies          *Result `json:"party_species"`
+		PartyType             *Result `json:"party_type"`
+		RelativePhysicalStats *int    `json:"relative_physical_stats"`
+		TimeOfDay             string  `json:"time_of_day"`
+		TradeSpecies          *Result `json:"trade_species"`
+		TurnUpsideDown        bool    `json:"turn_upside_down"`
+	} `json:"evolution_details"`
+	EvolvesTo []ChainLink `json:"evolves_to"`
+}
+
+type EvolutionChain struct {
+	ID              int       `json:"id"`
+	BabyTriggerItem *Result   `json:"baby_trigger_item"`
+	Chain           ChainLink `json:"chain"`
+}
+
+type EvolutionTrigger struct {
+	ID             int      `json:"id"`
+	Name           string   `json:"name"`
+	Names          []Name   `json:"names"`
+	PokemonSpecies []Result `json:"pokemon_species"`
+}
+
+// Games
+
+type Version struct {
+	ID           int               `json:"id"`
+	Name         string            `json:"name"`
+	Names        []Name            `json:"names"`
+	VersionGroup Ref[VersionGroup] `json:"version_group"`
+}
+
+type VersionGroup struct {
+	ID               int      `json:"id"`
+	Name             string   `json:"name"`
+	Order            int      `json:"order"`
+	Generation       Result   `json:"generation"`
+	MoveLearnMethods []Result `json:"move_learn_methods"`
+	Pokedexes        []Result `json:"pokedexes"`
+	Regions          []Result `json:"regions"`
+	Versions         []Result `json:"versions"`
+}
+
+// Items
+
+type Item struct {
+	ID                int                      `json:"id"`
+	Name              string                   `json:"name"`
+	Cost              int                      `json:"cost"`
+	FlingPower        *int                     `json:"fling_power"`
+	FlingEffect       *Result                  `json:"fling_effect"`
+	Attributes        []Result                 `json:"attributes"`
+	Category          Result                   `json:"category"`
+	EffectEntries     []VerboseEffect          `json:"effect_entries"`
+	FlavorTextEntries []VersionGroupFlavorText `json:"flavor_text_entries"`
+	GameIndices       []GenerationGameIndex    `json:"game_indices"`
+	Names             []Name                   `json:"names"`
+	Sprites           struct {
+		Default string `json:"default"`
+	} `json:"sprites"`
+	HeldByPokemon []struct {
+		Pokemon        Result `json:"pokemon"`
+		VersionDetails []struct {
+			Rarity  int    `json:"rarity"`
+			Version Result `json:"version"`
+		} `json:"version_details"`
+	} `json:"held_by_pokemon"`
+	BabyTriggerFor *APIResource           `json:"baby_trigger_for"`
+	Machines       []MachineVersionDetail `json:"machines"`
+}
+
+type ItemAttribute struct {
+	ID           int           `json:"id"`
+	Name         string        `json:"name"`
+	Items        []Result      `json:"items"`
+	Names        []Name        `json:"names"`
+	Descriptions []Description `json:"descriptions"`
+}
+
+type ItemCategory struct {
+	ID     int      `json:"id"`
+	Name   string   `json:"name"`
+	Items  []Result `json:"items"`
+	Names  []Name   `json:"names"`
+	Pocket Result   `json:"pocket"`
+}
+
+type ItemFlingEffect struct {
+	ID            int      `json:"id"`
+	Name          string   `json:"name"`
+	EffectEntries []Effect `json:"effect_entries"`
+	Items         []Result `json:"items"`
+}
+
+type ItemPocket struct {
+	ID         int      `json:"id"`
+	Name       string   `json:"name"`
+	Categories []Result `json:"categories"`
+	Names      []Name   `json:"names"`
+}
+
+// Locations
+
+type Location struct {
+	ID          int                   `json:"id"`
+	Name        string                `json:"name"`
+	Region      *Result               `json:"region"`
+	Names       []Name                `json:"names"`
+	GameIndices []GenerationGameIndex `json:"game_indices"`
+	Areas       []Ref[LocationArea]   `json:"areas"`
+}
+
+type PalParkArea struct {
+	ID                int    `json:"id"`
+	Name              string `json:"name"`
+	Names             []Name `json:"names"`
+	PokemonEncounters []struct {
+		BaseScore      int    `json:"base_score"`
+		Rate           int    `json:"rate"`
+		PokemonSpecies Result `json:"pokemon_species"`
+	} `json:"pokemon_encounters"`
+}
+
+type Region struct {
+	ID             int      `json:"id"`
+	Name           string   `json:"name"`
+	Locations      []Result `json:"locations"`
+	MainGeneration *Result  `json:"main_generation"`
+	Names          []Name   `json:"names"`
+	Pokedexes      []Result `json:"pokedexes"`
+	VersionGroups  []Result `json:"version_groups"`
+}
+
+// Machines
+
+type Machine struct {
+	ID           int    `json:"id"`
+	Item         Result `json:"item"`
+	Move         Result `json:"move"`
+	VersionGroup Result `json:"version_group"`
+}
+
+// Moves
+
+type Move struct {
+	ID                int                      `json:"id"`
+	Name              string                   `json:"name"`
+	Accuracy          *int                     `json:"accuracy"`
+	EffectChance      *int                     `json:"effect_chance"`
+	PP                *int                     `json:"pp"`
+	Priority          int                      `json:"priority"`
+	Power             *int                     `json:"power"`
+	ContestCombos     *ContestComboSets        `json:"contest_combos"`
+	ContestType       *Result                  `json:"contest_type"`
+	ContestEffect     *APIResource             `json:"contest_effect"`
+	DamageClass       Result                   `json:"damage_class"`
+	EffectEntries     []VerboseEffect          `json:"effect_entries"`
+	EffectChanges     []AbilityEffectChange    `json:"effect_changes"`
+	LearnedByPokemon  []Result                 `json:"learned_by_pokemon"`
+	FlavorTextEntries []VersionGroupFlavorText `json:"flavor_text_entries"`
+	Generation        Result                   `json:"generation"`
+	Machines          []MachineVersionDetail   `json:"machines"`
+	Meta              *MoveMetaData            `json:"meta"`
+	Names             []Name                   `json:"names"`
+	PastValues        []struct {
+		Accuracy      *int            `json:"accuracy"`
+		EffectChance  *int            `json:"effect_chance"`
+		Power         *int            `json:"power"`
+		PP            *int            `json:"pp"`
+		EffectEntries []VerboseEffect `json:"effect_entries"`
+		Type          *Result         `json:"type"`
+		VersionGroup  Result          `json:"version_group"`
+	} `json:"past_values"`
+	StatChanges []struct {
+		Change int    `json:"change"`
+		Stat   Result `json:"stat"`
+	} `json:"stat_changes"`
+	SuperContestEffect *APIResource    `json:"super_contest_effect"`
+	Target             Ref[MoveTarget] `json:"target"`
+	Type               Ref[Type]       `json:"type"`
+}
+
+type ContestComboSets struct {
+	Normal *ContestComboDetail `json:"normal"`
+	Super  *ContestComboDetail `json:"super"`
+}
+
+type ContestComboDetail struct {
+	UseBefore []Result `json:"use_before"`
+	UseAfter  []Result `json:"use_after"`
+}
+
+type MoveMetaData struct {
+	Ailment       Result `json:"ailment"`
+	Category      Result `json:"category"`
+	MinHits       *int   `json:"min_hits"`
+	MaxHits       *int   `json:"max_hits"`
+	MinTurns      *int   `json:"min_turns"`
+	MaxTurns      *int   `json:"max_turns"`
+	Drain         int    `json:"drain"`
+	Healing       int    `json:"healing"`
+	CritRate      int    `json:"crit_rate"`
+	AilmentChance int    `json:"ailment_chance"`
+	FlinchChance  int    `json:"flinch_chance"`
+	StatChance    int    `json:"stat_chance"`
+}
+
+type MoveAilment struct {
+	ID    int      `json:"id"`
+	Name  string   `json:"name"`
+	Moves []Result `json:"moves"`
+	Names []Name   `json:"names"`
+}
+
+type MoveBattleStyle struct {
+	ID    int    `json:"id"`
+	Name  string `json:"name"`
+	Names []Name `json:"names"`
+}
+
+type MoveCategory struct {
+	ID           int           `json:"id"`
+	Name         string        `json:"name"`
+	Moves        []Result      `json:"moves"`
+	Descriptions []Description `json:"descriptions"`
+}
+
+type MoveDamageClass struct {
+	ID           int           `json:"id"`
+	Name         string        `json:"name"`
+	Descriptions []Description `json:"descriptions"`
+	Moves        []Result      `json:"moves"`
+	Names        []Name        `json:"names"`
+}
+
+type MoveLearnMethod struct {
+	ID            int           `json:"id"`
+	Name          string        `json:"name"`
+	Descriptions  []Description `json:"descriptions"`
+	Names         []Name        `json:"names"`
+	VersionGroups []Result      `json:"version_groups"`
+}
+
+type MoveTarget struct {
+	ID           int           `json:"id"`
+	Name         string        `json:"name"`
+	Descriptions []Description `json:"descriptions"`
+	Moves        []Result      `json:"moves"`
+	Names        []Name        `json:"names"`
+}
+
+// Pokemon
+
+type Ability struct {
+	ID                int                      `json:"id"`
+	Name              string                   `json:"name"`
+	IsMainSeries      bool                     `json:"is_main_series"`
+	Generation        Result                   `json:"generation"`
+	Names             []Name                   `json:"names"`
+	EffectEntries     []VerboseEffect          `json:"effect_entries"`
+	EffectChanges     []AbilityEffectChange    `json:"effect_changes"`
+	FlavorTextEntries []VersionGroupFlavorText `json:"flavor_text_entries"`
+	Pokemon           []struct {
+		IsHidden bool   `json:"is_hidden"`
+		Slot     int    `json:"slot"`
+		Pokemon  Result `json:"pokemon"`
+	} `json:"pokemon"`
+}
+
+type AbilityEffectChange struct {
+	EffectEntries []Effect `json:"effect_entries"`
+	VersionGroup  Result   `json:"version_group"`
+}
+
+type Characteristic struct {
+	ID             int           `json:"id"`
+	GeneModulo     int           `json:"gene_modulo"`
+	PossibleValues []int         `json:"possible_values"`
+	HighestStat    Result        `json:"highest_stat"`
+	Descriptions   []Description `json:"descriptions"`
+}
+
+type EggGroup struct {
+	ID             int      `json:"id"`
+	Name           string   `json:"name"`
+	Names          []Name   `json:"names"`
+	PokemonSpecies []Result `json:"pokemon_species"`
+}
+
+type Gender struct {
+	ID                    int    `json:"id"`
+	Name                  string `json:"name"`
+	PokemonSpeciesDetails []struct {
+		Rate           int    `json:"rate"`
+		PokemonSpecies Result `json:"pokemon_species"`
+	} `json:"pokemon_species_details"`
+	RequiredForEvolution []Result `json:"required_for_evolution"`
+}
+
+type GrowthRate struct {
+	ID           int           `json:"id"`
+	Name         string        `json:"name"`
+	Formula      string        `json:"formula"`
+	Descriptions []Description `json:"descriptions"`
+	Levels       []struct {
+		Level      int `json:"level"`
+		Experience int `json:"experience"`
+	} `json:"levels"`
+	PokemonSpecies []Result `json:"pokemon_species"`
+}
+
+type Nature struct {
+	ID                    int     `json:"id"`
+	Name                  string  `json:"name"`
+	DecreasedStat         *Result `json:"decreased_stat"`
+	IncreasedStat         *Result `json:"increased_stat"`
+	HatesFlavor           *Result `json:"hates_flavor"`
+	LikesFlavor           *Result `json:"likes_flavor"`
+	PokeathlonStatChanges []struct {
+		MaxChange      int    `json:"max_change"`
+		PokeathlonStat Result `json:"pokeathlon_stat"`
+	} `json:"pokeathlon_stat_changes"`
+	MoveBattleStylePreferences []struct {
+		LowHPPreference  int    `json:"low_hp_preference"`
+		HighHPPreference int    `json:"high_hp_preference"`
+		MoveBattleStyle  Result `json:"move_battle_style"`
+	} `json:"move_battle_style_preferences"`
+	Names []Name `json:"names"`
+}
+
+type PokeathlonStat struct {
+	ID               int    `json:"id"`
+	Name             string `json:"name"`
+	Names            []Name `json:"names"`
+	AffectingNatures struct {
+		Increase []struct {
+			MaxChange int    `json:"max_change"`
+			Nature    Result `json:"nature"`
+		} `json:"increase"`
+		Decrease []struct {
+			MaxChange int    `json:"max_change"`
+			Nature    Result `json:"nature"`
+		} `json:"decrease"`
+	} `json:"affecting_natures"`
+}
+
+// LocationAreaEncounter is one entry of the list at
+// Pokemon.LocationAreaEncounters.
+type LocationAreaEncounter struct {
+	LocationArea   Ref[LocationArea]        `json:"location_area"`
+	VersionDetails []VersionEncounterDetail `json:"version_details"`
+}
+
+type PokemonColor struct {
+	ID             int      `json:"id"`
+	Name           string   `json:"name"`
+	Names          []Name   `json:"names"`
+	PokemonSpecies []Result `json:"pokemon_species"`
+}
+
+type PokemonForm struct {
+	ID           int    `json:"id"`
+	Name         string `json:"name"`
+	Order        int    `json:"order"`
+	FormOrder    int    `json:"form_order"`
+	IsDefault    bool   `json:"is_default"`
+	IsBattleOnly bool   `json:"is_battle_only"`
+	IsMega       bool   `json:"is_mega"`
+	FormName     string `json:"form_name"`
+	Pokemon      Result `json:"pokemon"`
+	Types        []struct {
+		Slot int    `json:"slot"`
+		Type Result `json:"type"`
+	} `json:"types"`
+	Sprites struct {
+		FrontDefault string `json:"front_default"`
+		FrontShiny   string `json:"front_shiny"`
+		BackDefault  string `json:"back_default"`
+		BackShiny    string `json:"back_shiny"`
+	} `json:"sprites"`
+	VersionGroup Result `json:"version_group"`
+	Names        []Name `json:"names"`
+	FormNames    []Name `json:"form_names"`
+}
+
+type PokemonHabitat struct {
+	ID             int      `json:"id"`
+	Name           string   `json:"name"`
+	Names          []Name   `json:"names"`
+	PokemonSpecies []Result `json:"pokemon_species"`
+}
+
+type PokemonShape struct {
+	ID           int    `json:"id"`
+	Name         string `json:"name"`
+	AwesomeNames []struct {
+		AwesomeName string `json:"awesome_name"`
+		Language    Result `json:"language"`
+	} `json:"awesome_names"`
+	Names          []Name   `json:"names"`
+	PokemonSpecies []Result `json:"pokemon_species"`
+}
+
+type Stat struct {
+	ID             int    `json:"id"`
+	Name           string `json:"name"`
+	GameIndex      int    `json:"game_index"`
+	IsBattleOnly   bool   `json:"is_battle_only"`
+	AffectingMoves struct {
+		Increase []struct {
+			Change int    `json:"change"`
+			Move   Result `json:"move"`
+		} `json:"increase"`
+		Decrease []struct {
+			Change int    `json:"change"`
+			Move   Result `json:"move"`
+		} `json:"decrease"`
+	} `json:"affecting_moves"`
+	AffectingNatures struct {
+		Increase []Result `json:"increase"`
+		Decrease []Result `json:"decrease"`
+	} `json:"affecting_natures"`
+	Characteristics []APIResource `json:"characteristics"`
+	MoveDamageClass *Result       `json:"move_damage_class"`
+	Names           []Name        `json:"names"`
+}
+
+type Type struct {
+	ID                  int           `json:"id"`
+	Name                string        `json:"name"`
+	DamageRelations     TypeRelations `json:"damage_relations"`
+	PastDamageRelations []struct {
+		Generation      Result        `json:"generation"`
+		DamageRelations TypeRelations `json:"damage_relations"`
+	} `json:"past_damage_relations"`
+	GameIndices     []GenerationGameIndex `json:"game_indices"`
+	Generation      Result                `json:"generation"`
+	MoveDamageClass *Result               `json:"move_damage_class"`
+	Names           []Name                `json:"names"`
+	Pokemon         []struct {
+		Slot    int          `json:"slot"`
+		Pokemon Ref[Pokemon] `json:"pokemon"`
+	} `json:"pokemon"`
+	Moves []Ref[Move] `json:"moves"`
+}
+
+// TypeRelations lists which types a type is strong or weak against, both
+// when attacking and when being attacked.
+type TypeRelations struct {
+	NoDamageTo       []Result `json:"no_damage_to"`
+	HalfDamageTo     []Result `json:"half_damage_to"`
+	DoubleDamageTo   []Result `json:"double_damage_to"`
+	NoDamageFrom     []Result `json:"no_damage_from"`
+	HalfDamageFrom   []Result `json:"half_damage_from"`
+	DoubleDamageFrom []Result `json:"double_damage_from"`
+}
+
+// Utility
+
+type Language struct {
+	ID       int    `json:"id"`
+	Name     string `json:"name"`
+	Official bool   `json:"official"`
+	ISO639   string `json:"iso639"`
+	ISO3166  string `json:"iso3166"`
+	Names    []Name `json:"names"`
+}
@@ -0,0 +1,303 @@
+// Code generated by go run ./gen; DO NOT EDIT.
+
+package pokeapi
+
+import (
+	"context"
+	"strconv"
+)
+
+// apiResponse lists every type GetFromAPI can decode.
+type apiResponse interface {
+	LocationAreaList |
+		NamedResourceList |
+		[]LocationAreaEncounter |
+		Ability |
+		Berry |
+		BerryFirmness |
+		BerryFlavor |
+		Characteristic |
+		ContestEffect |
+		ContestType |
+		EggGroup |
+		EncounterCondition |
+		EncounterConditionValue |
+		EncounterMethod |
+		EvolutionChain |
+		EvolutionTrigger |
+		Gender |
+		Generation |
+		GrowthRate |
+		Item |
+		ItemAttribute |
+		ItemCategory |
+		ItemFlingEffect |
+		ItemPocket |
+		Language |
+		Location |
+		LocationArea |
+		Machine |
+		Move |
+		MoveAilment |
+		MoveBattleStyle |
+		MoveCategory |
+		MoveDamageClass |
+		MoveLearnMethod |
+		MoveTarget |
+		Nature |
+		PalParkArea |
+		PokeathlonStat |
+		Pokedex |
+		Pokemon |
+		PokemonColor |
+		PokemonForm |
+		PokemonHabitat |
+		PokemonShape |
+		PokemonSpecies |
+		Region |
+		Stat |
+		SuperContestEffect |
+		Type |
+		Version |
+		VersionGroup
+}
+
+// GetAbility fetches /ability/{name or id}.
+func GetAbility(ctx context.Context, nameOrID string) (Ability, error) {
+	return GetFromAPI[Ability](ctx, BaseURL+"ability/"+nameOrID)
+}
+
+// GetBerry fetches /berry/{name or id}.
+func GetBerry(ctx context.Context, nameOrID string) (Berry, error) {
+	return GetFromAPI[Berry](ctx, BaseURL+"berry/"+nameOrID)
+}
+
+// GetBerryFirmness fetches /berry-firmness/{name or id}.
+func GetBerryFirmness(ctx context.Context, nameOrID string) (BerryFirmness, error) {
+	return GetFromAPI[BerryFirmness](ctx, BaseURL+"berry-firmness/"+nameOrID)
+}
+
+// GetBerryFlavor fetches /berry-flavor/{name or id}.
+func GetBerryFlavor(ctx context.Context, nameOrID string) (BerryFlavor, error) {
+	return GetFromAPI[BerryFlavor](ctx, BaseURL+"berry-flavor/"+nameOrID)
+}
+
+// GetCharacteristic fetches /characteristic/{id}.
+func GetCharacteristic(ctx context.Context, id int) (Characteristic, error) {
+	return GetFromAPI[Characteristic](ctx, BaseURL+"characteristic/"+strconv.Itoa(id))
+}
+
+// GetContestEffect fetches /contest-effect/{id}.
+func GetContestEffect(ctx context.Context, id int) (ContestEffect, error) {
+	return GetFromAPI[ContestEffect](ctx, BaseURL+"contest-effect/"+strconv.Itoa(id))
+}
+
+// GetContestType fetches /contest-type/{name or id}.
+func GetContestType(ctx context.Context, nameOrID string) (ContestType, error) {
+	return GetFromAPI[ContestType](ctx, BaseURL+"contest-type/"+nameOrID)
+}
+
+// GetEggGroup fetches /egg-group/{name or id}.
+func GetEggGroup(ctx context.Context, nameOrID string) (EggGroup, error) {
+	return GetFromAPI[EggGroup](ctx, BaseURL+"egg-group/"+nameOrID)
+}
+
+// GetEncounterCondition fetches /encounter-condition/{name or id}.
+func GetEncounterCondition(ctx context.Context, nameOrID string) (EncounterCondition, error) {
+	return GetFromAPI[EncounterCondition](ctx, BaseURL+"encounter-condition/"+nameOrID)
+}
+
+// GetEncounterConditionValue fetches /encounter-condition-value/{name or id}.
+func GetEncounterConditionValue(ctx context.Context, nameOrID string) (EncounterConditionValue, error) {
+	return GetFromAPI[EncounterConditionValue](ctx, BaseURL+"encounter-condition-value/"+nameOrID)
+}
+
+// GetEncounterMethod fetches /encounter-method/{name or id}.
+func GetEncounterMethod(ctx context.Context, nameOrID string) (EncounterMethod, error) {
+	return GetFromAPI[EncounterMethod](ctx, BaseURL+"encounter-method/"+nameOrID)
+}
+
+// GetEvolutionChain fetches /evolution-chain/{id}.
+func GetEvolutionChain(ctx context.Context, id int) (EvolutionChain, error) {
+	return GetFromAPI[EvolutionChain](ctx, BaseURL+"evolution-chain/"+strconv.Itoa(id))
+}
+
+// GetEvolutionTrigger fetches /evolution-trigger/{name or id}.
+func GetEvolutionTrigger(ctx context.Context, nameOrID string) (EvolutionTrigger, error) {
+	return GetFromAPI[EvolutionTrigger](ctx, BaseURL+"evolution-trigger/"+nameOrID)
+}
+
+// GetGender fetches /gender/{name or id}.
+func GetGender(ctx context.Context, nameOrID string) (Gender, error) {
+	return GetFromAPI[Gender](ctx, BaseURL+"gender/"+nameOrID)
+}
+
+// GetGeneration fetches /generation/{name or id}.
+func GetGeneration(ctx context.Context, nameOrID string) (Generation, error) {
+	return GetFromAPI[Generation](ctx, BaseURL+"generation/"+nameOrID)
+}
+
+// GetGrowthRate fetches /growth-rate/{name or id}.
+func GetGrowthRate(ctx context.Context, nameOrID string) (GrowthRate, error) {
+	return GetFromAPI[GrowthRate](ctx, BaseURL+"growth-rate/"+nameOrID)
+}
+
+// GetItem fetches /item/{name or id}.
+func GetItem(ctx context.Context, nameOrID string) (Item, error) {
+	return GetFromAPI[Item](ctx, BaseURL+"item/"+nameOrID)
+}
+
+// GetItemAttribute fetches /item-attribute/{name or id}.
+func GetItemAttribute(ctx context.Context, nameOrID string) (ItemAttribute, error) {
+	return GetFromAPI[ItemAttribute](ctx, BaseURL+"item-attribute/"+nameOrID)
+}
+
+// GetItemCategory fetches /item-category/{name or id}.
+func GetItemCategory(ctx context.Context, nameOrID string) (ItemCategory, error) {
+	return GetFromAPI[ItemCategory](ctx, BaseURL+"item-category/"+nameOrID)
+}
+
+// GetItemFlingEffect fetches /item-fling-effect/{name or id}.
+func GetItemFlingEffect(ctx context.Context, nameOrID string) (ItemFlingEffect, error) {
+	return GetFromAPI[ItemFlingEffect](ctx, BaseURL+"item-fling-effect/"+nameOrID)
+}
+
+// GetItemPocket fetches /item-pocket/{name or id}.
+func GetItemPocket(ctx context.Context, nameOrID string) (ItemPocket, error) {
+	return GetFromAPI[ItemPocket](ctx, BaseURL+"item-pocket/"+nameOrID)
+}
+
+// GetLanguage fetches /language/{name or id}.
+func GetLanguage(ctx context.Context, nameOrID string) (Language, error) {
+	return GetFromAPI[Language](ctx, BaseURL+"language/"+nameOrID)
+}
+
+// GetLocation fetches /location/{name or id}.
+func GetLocation(ctx context.Context, nameOrID string) (Location, error) {
+	return GetFromAPI[Location](ctx, BaseURL+"location/"+nameOrID)
+}
+
+// GetLocationArea fetches /location-area/{name or id}.
+func GetLocationArea(ctx context.Context, nameOrID string) (LocationArea, error) {
+	return GetFromAPI[LocationArea](ctx, BaseURL+"location-area/"+nameOrID)
+}
+
+// GetMachine fetches /machine/{id}.
+func GetMachine(ctx context.Context, id int) (Machine, error) {
+	return GetFromAPI[Machine](ctx, BaseURL+"machine/"+strconv.Itoa(id))
+}
+
+// GetMove fetches /move/{name or id}.
+func GetMove(ctx context.Context, nameOrID string) (Move, error) {
+	return GetFromAPI[Move](ctx, BaseURL+"move/"+nameOrID)
+}
+
+// GetMoveAilment fetches /move-ailment/{name or id}.
+func GetMoveAilment(ctx context.Context, nameOrID string) (MoveAilment, error) {
+	return GetFromAPI[MoveAilment](ctx, BaseURL+"move-ailment/"+nameOrID)
+}
+
+// GetMoveBattleStyle fetches /move-battle-style/{name or id}.
+func GetMoveBattleStyle(ctx context.Context, nameOrID string) (MoveBattleStyle, error) {
+	return GetFromAPI[MoveBattleStyle](ctx, BaseURL+"move-battle-style/"+nameOrID)
+}
+
+// GetMoveCategory fetches /move-category/{name or id}.
+func GetMoveCategory(ctx context.Context, nameOrID string) (MoveCategory, error) {
+	return GetFromAPI[MoveCategory](ctx, BaseURL+"move-category/"+nameOrID)
+}
+
+// GetMoveDamageClass fetches /move-damage-class/{name or id}.
+func GetMoveDamageClass(ctx context.Context, nameOrID string) (MoveDamageClass, error) {
+	return GetFromAPI[MoveDamageClass](ctx, BaseURL+"move-damage-class/"+nameOrID)
+}
+
+// GetMoveLearnMethod fetches /move-learn-method/{name or id}.
+func GetMoveLearnMethod(ctx context.Context, nameOrID string) (MoveLearnMethod, error) {
+	return GetFromAPI[MoveLearnMethod](ctx, BaseURL+"move-learn-method/"+nameOrID)
+}
+
+// GetMoveTarget fetches /move-target/{name or id}.
+func GetMoveTarget(ctx context.Context, nameOrID string) (MoveTarget, error) {
+	return GetFromAPI[MoveTarget](ctx, BaseURL+"move-target/"+nameOrID)
+}
+
+// GetNature fetches /nature/{name or id}.
+func GetNature(ctx context.Context, nameOrID string) (Nature, error) {
+	return GetFromAPI[Nature](ctx, BaseURL+"nature/"+nameOrID)
+}
+
+// GetPalParkArea fetches /pal-park-area/{name or id}.
+func GetPalParkArea(ctx context.Context, nameOrID string) (PalParkArea, error) {
+	return GetFromAPI[PalParkArea](ctx, BaseURL+"pal-park-area/"+nameOrID)
+}
+
+// GetPokeathlonStat fetches /pokeathlon-stat/{name or id}.
+func GetPokeathlonStat(ctx context.Context, nameOrID string) (PokeathlonStat, error) {
+	return GetFromAPI[PokeathlonStat](ctx, BaseURL+"pokeathlon-stat/"+nameOrID)
+}
+
+// GetPokedex fetches /pokedex/{name or id}.
+func GetPokedex(ctx context.Context, nameOrID string) (Pokedex, error) {
+	return GetFromAPI[Pokedex](ctx, BaseURL+"pokedex/"+nameOrID)
+}
+
+// GetPokemon fetches /pokemon/{name or id}.
+func GetPokemon(ctx context.Context, nameOrID string) (Pokemon, error) {
+	return GetFromAPI[Pokemon](ctx, BaseURL+"pokemon/"+nameOrID)
+}
+
+// GetPokemonColor fetches /pokemon-color/{name or id}.
+func GetPokemonColor(ctx context.Context, nameOrID string) (PokemonColor, error) {
+	return GetFromAPI[PokemonColor](ctx, BaseURL+"pokemon-color/"+nameOrID)
+}
+
+// GetPokemonForm fetches /pokemon-form/{name or id}.
+func GetPokemonForm(ctx context.Context, nameOrID string) (PokemonForm, error) {
+	return GetFromAPI[PokemonForm](ctx, BaseURL+"pokemon-form/"+nameOrID)
+}
+
+// GetPokemonHabitat fetches /pokemon-habitat/{name or id}.
+func GetPokemonHabitat(ctx context.Context, nameOrID string) (PokemonHabitat, error) {
+	return GetFromAPI[PokemonHabitat](ctx, BaseURL+"pokemon-habitat/"+nameOrID)
+}
+
+// GetPokemonShape fetches /pokemon-shape/{name or id}.
+func GetPokemonShape(ctx context.Context, nameOrID string) (PokemonShape, error) {
+	return GetFromAPI[PokemonShape](ctx, BaseURL+"pokemon-shape/"+nameOrID)
+}
+
+// GetPokemonSpecies fetches /pokemon-species/{name or id}.
+func GetPokemonSpecies(ctx context.Context, nameOrID string) (PokemonSpecies, error) {
+	return GetFromAPI[PokemonSpecies](ctx, BaseURL+"pokemon-species/"+nameOrID)
+}
+
+// GetRegion fetches /region/{name or id}.
+func GetRegion(ctx context.Context, nameOrID string) (Region, error) {
+	return GetFromAPI[Region](ctx, BaseURL+"region/"+nameOrID)
+}
+
+// GetStat fetches /stat/{name or id}.
+func GetStat(ctx context.Context, nameOrID string) (Stat, error) {
+	return GetFromAPI[Stat](ctx, BaseURL+"stat/"+nameOrID)
+}
+
+// GetSuperContestEffect fetches /super-contest-effect/{id}.
+func GetSuperContestEffect(ctx context.Context, id int) (SuperContestEffect, error) {
+	return GetFromAPI[SuperContestEffect](ctx, BaseURL+"super-contest-effect/"+strconv.Itoa(id))
+}
+
+// GetType fetches /type/{name or id}.
+func GetType(ctx context.Context, nameOrID string) (Type, error) {
+	return GetFromAPI[Type](ctx, BaseURL+"type/"+nameOrID)
+}
+
+// GetVersion fetches /version/{name or id}.
+func GetVersion(ctx context.Context, nameOrID string) (Version, error) {
+	return GetFromAPI[Version](ctx, BaseURL+"version/"+nameOrID)
+}
+
+// GetVersionGroup fetches /version-group/{name or id}.
+func GetVersionGroup(ctx context.Context, nameOrID string) (VersionGroup, error) {
+	return GetFromAPI[VersionGroup](ctx, BaseURL+"version-group/"+nameOrID)
+}
@@ -1,7 +1,17 @@
 package pokeapi
 
-type apiResponse interface {
-	LocationAreaList | LocationArea | Pokemon
+import (
+	"strconv"
+	"strings"
+)
+
+// NamedResourceList is a page of any named resource list endpoint, such as
+// /generation or /pokedex.
+type NamedResourceList struct {
+	Count    int64    `json:"count"`
+	Next     string   `json:"next"`
+	Previous *string  `json:"previous"`
+	Results  []Result `json:"results"`
 }
 
 type LocationAreaList struct {
@@ -16,138 +26,82 @@ type Result struct {
 	URL  string `json:"url"`
 }
 
+// ID returns the numeric id at the end of the resource URL, or 0 when the URL
+// doesn't end in one.
+func (r Result) ID() int {
+	segments := strings.Split(strings.TrimSuffix(r.URL, "/"), "/")
+	id, err := strconv.Atoi(segments[len(segments)-1])
+	if err != nil {
+		return 0
+	}
+	return id
+}
+
 type LocationArea struct {
 	EncounterMethodRates []struct {
-		EncounterMethod struct {
-			Name string `json:"name"`
-			URL  string `json:"url"`
-		} `json:"encounter_method"`
-		VersionDetails []struct {
-			Rate    int `json:"rate"`
-			Version struct {
-				Name string `json:"name"`
-				URL  string `json:"url"`
-			} `json:"version"`
+		EncounterMethod Ref[EncounterMethod] `json:"encounter_method"`
+		VersionDetails  []struct {
+			Rate    int          `json:"rate"`
+			Version Ref[Version] `json:"version"`
 		} `json:"version_details"`
 	} `json:"encounter_method_rates"`
-	GameIndex int `json:"game_index"`
-	ID        int `json:"id"`
-	Location  struct {
-		Name string `json:"name"`
-		URL  string `json:"url"`
-	} `json:"location"`
-	Name  string `json:"name"`
-	Names []struct {
-		Language struct {
-			Name string `json:"name"`
-			URL  string `json:"url"`
-		} `json:"language"`
-		Name string `json:"name"`
-	} `json:"names"`
+	GameIndex         int           `json:"game_index"`
+	ID                int           `json:"id"`
+	Location          Ref[Location] `json:"location"`
+	Name              string        `json:"name"`
+	Names             []Name        `json:"names"`
 	PokemonEncounters []struct {
-		Pokemon struct {
-			Name string `json:"name"`
-			URL  string `json:"url"`
-		} `json:"pokemon"`
-		VersionDetails []struct {
-			EncounterDetails []struct {
-				Chance          int   `json:"chance"`
-				ConditionValues []any `json:"condition_values"`
-				MaxLevel        int   `json:"max_level"`
-				Method          struct {
-					Name string `json:"name"`
-					URL  string `json:"url"`
-				} `json:"method"`
-				MinLevel int `json:"min_level"`
-			} `json:"encounter_details"`
-			MaxChance int `json:"max_chance"`
-			Version   struct {
-				Name string `json:"name"`
-				URL  string `json:"url"`
-			} `json:"version"`
-		} `json:"version_details"`
+		Pokemon        Ref[Pokemon]             `json:"pokemon"`
+		VersionDetails []VersionEncounterDetail `json:"version_details"`
 	} `json:"pokemon_encounters"`
 }
 
 type Pokemon struct {
 	Abilities []struct {
-		Ability struct {
-			Name string `json:"name"`
-			URL  string `json:"url"`
-		} `json:"ability"`
-		IsHidden bool `json:"is_hidden"`
-		Slot     int  `json:"slot"`
+		Ability  Ref[Ability] `json:"ability"`
+		IsHidden bool         `json:"is_hidden"`
+		Slot     int          `json:"slot"`
 	} `json:"abilities"`
 	BaseExperience int `json:"base_experience"`
 	Cries          struct {
 		Latest string `json:"latest"`
 		Legacy string `json:"legacy"`
 	} `json:"cries"`
-	Forms []struct {
-		Name string `json:"name"`
-		URL  string `json:"url"`
-	} `json:"forms"`
+	Forms       []Ref[PokemonForm] `json:"forms"`
 	GameIndices []struct {
-		GameIndex int `json:"game_index"`
-		Version   struct {
-			Name string `json:"name"`
-			URL  string `json:"url"`
-		} `json:"version"`
+		GameIndex int          `json:"game_index"`
+		Version   Ref[Version] `json:"version"`
 	} `json:"game_indices"`
 	Height    int `json:"height"`
 	HeldItems []struct {
-		Item struct {
-			Name string `json:"name"`
-			URL  string `json:"url"`
-		} `json:"item"`
+		Item           Ref[Item] `json:"item"`
 		VersionDetails []struct {
-			Rarity  int `json:"rarity"`
-			Version struct {
-				Name string `json:"name"`
-				URL  string `json:"url"`
-			} `json:"version"`
+			Rarity  int          `json:"rarity"`
+			Version Ref[Version] `json:"version"`
 		} `json:"version_details"`
 	} `json:"held_items"`
 	ID                     int    `json:"id"`
 	IsDefault              bool   `json:"is_default"`
 	LocationAreaEncounters string `json:"location_area_encounters"`
 	Moves                  []struct {
-		Move struct {
-			Name string `json:"name"`
-			URL  string `json:"url"`
-		} `json:"move"`
+		Move                Ref[Move] `json:"move"`
 		VersionGroupDetails []struct {
-			LevelLearnedAt  int `json:"level_learned_at"`
-			MoveLearnMethod struct {
-				Name string `json:"name"`
-				URL  string `json:"url"`
-			} `json:"move_learn_method"`
-			VersionGroup struct {
-				Name string `json:"name"`
-				URL  string `json:"url"`
-			} `json:"version_group"`
+			LevelLearnedAt  int                  `json:"level_learned_at"`
+			MoveLearnMethod Ref[MoveLearnMethod] `json:"move_learn_method"`
+			VersionGroup    Ref[VersionGroup]    `json:"version_group"`
 		} `json:"version_group_details"`
 	} `json:"moves"`
 	Name          string `json:"name"`
 	Order         int    `json:"order"`
 	PastAbilities []any  `json:"past_abilities"`
 	PastTypes     []struct {
-		Generation struct {
-			Name string `json:"name"`
-			URL  string `json:"url"`
-		} `json:"generation"`
-		Types []struct {
-			Slot int `json:"slot"`
-			Type struct {
-				Name string `json:"name"`
-				URL  string `json:"url"`
-			} `json:"type"`
+		Generation Ref[Generation] `json:"generation"`
+		Types      []struct {
+			Slot int       `json:"slot"`
+			Type Ref[Type] `json:"type"`
 		} `json:"types"`
 	} `json:"past_types"`
-	Species struct {
-		Name string `json:"name"`
-		URL  string `json:"url"`
-	} `json:"species"`
+	Species Ref[PokemonSpecies] `json:"species"`
 	Sprites struct {
 		BackDefault      string `json:"back_default"`
 		BackFemale       any    `json:"back_female"`
@@ -335,19 +289,70 @@ type Pokemon struct {
 		} `json:"versions"`
 	} `json:"sprites"`
 	Stats []struct {
-		BaseStat int `json:"base_stat"`
-		Effort   int `json:"effort"`
-		Stat     struct {
-			Name string `json:"name"`
-			URL  string `json:"url"`
-		} `json:"stat"`
+		BaseStat int       `json:"base_stat"`
+		Effort   int       `json:"effort"`
+		Stat     Ref[Stat] `json:"stat"`
 	} `json:"stats"`
 	Types []struct {
-		Slot int `json:"slot"`
-		Type struct {
-			Name string `json:"name"`
-			URL  string `json:"url"`
-		} `json:"type"`
+		Slot int       `json:"slot"`
+		Type Ref[Type] `json:"type"`
 	} `json:"types"`
 	Weight int `json:"weight"`
 }
+
+type Pokedex struct {
+	ID             int    `json:"id"`
+	Name           string `json:"name"`
+	IsMainSeries   bool   `json:"is_main_series"`
+	PokemonEntries []struct {
+		EntryNumber    int                 `json:"entry_number"`
+		PokemonSpecies Ref[PokemonSpecies] `json:"pokemon_species"`
+	} `json:"pokemon_entries"`
+	Region *Result `json:"region"`
+}
+
+type Generation struct {
+	ID             int                   `json:"id"`
+	Name           string                `json:"name"`
+	MainRegion     Result                `json:"main_region"`
+	PokemonSpecies []Ref[PokemonSpecies] `json:"pokemon_species"`
+}
+
+type PokemonSpecies struct {
+	ID                 int                  `json:"id"`
+	Name               string               `json:"name"`
+	Order              int                  `json:"order"`
+	BaseHappiness      int                  `json:"base_happiness"`
+	CaptureRate        int                  `json:"capture_rate"`
+	GenderRate         int                  `json:"gender_rate"`
+	HatchCounter       int                  `json:"hatch_counter"`
+	IsBaby             bool                 `json:"is_baby"`
+	IsLegendary        bool                 `json:"is_legendary"`
+	IsMythical         bool                 `json:"is_mythical"`
+	Color              Result               `json:"color"`
+	Shape              Result               `json:"shape"`
+	Habitat            *Result              `json:"habitat"`
+	GrowthRate         Result               `json:"growth_rate"`
+	Generation         Ref[Generation]      `json:"generation"`
+	EvolvesFromSpecies *Ref[PokemonSpecies] `json:"evolves_from_species"`
+	EvolutionChain     Ref[EvolutionChain]  `json:"evolution_chain"`
+	EggGroups          []Result             `json:"egg_groups"`
+	FlavorTextEntries  []struct {
+		FlavorText string       `json:"flavor_text"`
+		Language   Result       `json:"language"`
+		Version    Ref[Version] `json:"version"`
+	} `json:"flavor_text_entries"`
+	Genera []struct {
+		Genus    string `json:"genus"`
+		Language Result `json:"language"`
+	} `json:"genera"`
+	Names          []Name `json:"names"`
+	PokedexNumbers []struct {
+		EntryNumber int    `json:"entry_number"`
+		Pokedex     Result `json:"pokedex"`
+	} `json:"pokedex_numbers"`
+	Varieties []struct {
+		IsDefault bool         `json:"is_default"`
+		Pokemon   Ref[Pokemon] `json:"pokemon"`
+	} `json:"varieties"`
+}
@@ -8,6 +8,8 @@ import (
 type Cache struct {
 	stored map[string]cacheEntry
 	mu     sync.Mutex
+	stop   chan struct{}
+	closed bool
 }
 
 func (c *Cache) Add(key string, val []byte) {
@@ -31,12 +33,17 @@ func (c *Cache) Get(key string) ([]byte, bool) {
 	return record.val, true
 }
 
-func (c *Cache) reapLoop(interval time.Duration) {
+func (c *Cache) reapLoop(interval time.Duration, stop chan struct{}) {
 	ticker := time.NewTicker(interval)
 	// defer ticket.Stop()   <-- was here orignally
 	go func() {
 		defer ticker.Stop()
-		for range ticker.C {
+		for {
+			select {
+			case <-ticker.C:
+			case <-stop:
+				return
+			}
 			c.mu.Lock()
 			for key, entry := range c.stored {
 				expirationTime := entry.createdAt.Add(interval)
@@ -50,6 +57,33 @@ func (c *Cache) reapLoop(interval time.Duration) {
 	}()
 }
 
+// SetInterval changes how long entries are kept, including the ones already
+// cached. It does nothing once the cache is closed.
+func (c *Cache) SetInterval(interval time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.closed {
+		return
+	}
+	close(c.stop)
+	c.stop = make(chan struct{})
+	c.reapLoop(interval, c.stop)
+}
+
+// Close stops the cache from reaping expired entries. It should be called
+// once a cache is no longer used; closing it again does nothing.
+func (c *Cache) Close() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.closed {
+		return
+	}
+	c.closed = true
+	close(c.stop)
+}
+
 type cacheEntry struct {
 	createdAt time.Time
 	val       []byte
@@ -58,7 +92,8 @@ type cacheEntry struct {
 func NewCache(interval time.Duration) *Cache {
 	newCache := Cache{
 		stored: make(map[string]cacheEntry),
+		stop:   make(chan struct{}),
 	}
-	newCache.reapLoop(interval)
+	newCache.reapLoop(interval, newCache.stop)
 	return &newCache
 }
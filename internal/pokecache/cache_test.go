@@ -68,3 +68,30 @@ func TestReapLoop(t *testing.T) {
 		t.Log("Item was successfully reaped")
 	}
 }
+
+func TestSetInterval(t *testing.T) {
+	cache := NewCache(time.Hour)
+	defer cache.Close()
+	cache.Add("test", []byte("test data"))
+
+	cache.SetInterval(50 * time.Millisecond)
+	if _, exists := cache.Get("test"); !exists {
+		t.Fatal("Expected cached items to be kept when the interval changes")
+	}
+
+	time.Sleep(200 * time.Millisecond)
+	if _, exists := cache.Get("test"); exists {
+		t.Error("Expected item to be reaped after the new interval")
+	}
+}
+
+func TestCloseTwice(t *testing.T) {
+	cache := NewCache(time.Hour)
+	cache.Close()
+	cache.Close()
+	cache.SetInterval(time.Minute)
+	cache.Add("test", []byte("test data"))
+	if _, exists := cache.Get("test"); !exists {
+		t.Error("Expected a closed cache to keep working without reaping")
+	}
+}
@@ -0,0 +1,222 @@
+package termimg
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"os"
+	"strings"
+)
+
+// Protocol is a terminal graphics protocol that can display real images
+// rather than block art.
+type Protocol int
+
+const (
+	// NoProtocol means the terminal can only show text, so images are drawn
+	// as block art with Render.
+	NoProtocol Protocol = iota
+	Kitty
+	ITerm2
+	Sixel
+)
+
+// kittyChunkSize is the largest base64 payload kitty accepts per escape.
+const kittyChunkSize = 4096
+
+// sixelCellWidth is the width in pixels assumed for a terminal cell when
+// sizing sixel output, which draws one dot per pixel rather than filling
+// a number of columns.
+const sixelCellWidth = 8
+
+// ParseProtocol converts a protocol name as typed by the user into a Protocol.
+func ParseProtocol(name string) (Protocol, bool) {
+	switch name {
+	case "kitty":
+		return Kitty, true
+	case "iterm2", "iterm":
+		return ITerm2, true
+	case "sixel":
+		return Sixel, true
+	}
+	return NoProtocol, false
+}
+
+// DetectProtocol guesses the inline image protocol supported by the terminal
+// from the environment it set up.
+func DetectProtocol() Protocol {
+	term := os.Getenv("TERM")
+	termProgram := os.Getenv("TERM_PROGRAM")
+	switch {
+	case os.Getenv("KITTY_WINDOW_ID") != "" || term == "xterm-kitty" || termProgram == "ghostty":
+		return Kitty
+	case termProgram == "iTerm.app" || termProgram == "WezTerm" || os.Getenv("LC_TERMINAL") == "iTerm2":
+		return ITerm2
+	case strings.Contains(term, "sixel") || term == "foot" || term == "mlterm" || termProgram == "mintty":
+		return Sixel
+	}
+	return NoProtocol
+}
+
+// Inline encodes img for display with protocol p, sized to roughly columns
+// terminal cells wide where the protocol allows it.
+func Inline(img image.Image, p Protocol, columns int) (string, error) {
+	switch p {
+	case Kitty:
+		return kitty(img, columns)
+	case ITerm2:
+		return iterm2(img, columns)
+	case Sixel:
+		return sixel(resize(img, columns*sixelCellWidth)), nil
+	}
+	return "", fmt.Errorf("no inline image protocol selected")
+}
+
+func encodePNG(img image.Image) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return nil, fmt.Errorf("error encoding image: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+func kitty(img image.Image, columns int) (string, error) {
+	data, err := encodePNG(img)
+	if err != nil {
+		return "", err
+	}
+	payload := base64.StdEncoding.EncodeToString(data)
+
+	var out strings.Builder
+	for first := true; len(payload) > 0; first = false {
+		chunk := payload[:min(kittyChunkSize, len(payload))]
+		payload = payload[len(chunk):]
+		more := 0
+		if len(payload) > 0 {
+			more = 1
+		}
+		if first {
+			fmt.Fprintf(&out, "\x1b_Gf=100,a=T,c=%d,m=%d;%s\x1b\\", columns, more, chunk)
+		} else {
+			fmt.Fprintf(&out, "\x1b_Gm=%d;%s\x1b\\", more, chunk)
+		}
+	}
+	out.WriteString("\n")
+	return out.String(), nil
+}
+
+func iterm2(img image.Image, columns int) (string, error) {
+	data, err := encodePNG(img)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("\x1b]1337;File=inline=1;size=%d;width=%d;preserveAspectRatio=1:%s\a\n",
+		len(data), columns, base64.StdEncoding.EncodeToString(data)), nil
+}
+
+// sixel encodes img using the colors it contains, or the xterm color cube
+// when it has more colors than a sixel palette can hold. Transparent pixels
+// are left unpainted.
+func sixel(img image.Image) string {
+	bounds := img.Bounds()
+	palette := map[color.RGBA]int{}
+	indexes := make([][]int, bounds.Dy())
+	quantize := countColors(img) > 256
+	for y := range indexes {
+		indexes[y] = make([]int, bounds.Dx())
+		for x := range indexes[y] {
+			c := img.At(bounds.Min.X+x, bounds.Min.Y+y)
+			if !visible(c) {
+				indexes[y][x] = -1
+				continue
+			}
+			r, g, b := rgb(c)
+			key := color.RGBA{R: r, G: g, B: b, A: 0xff}
+			if quantize {
+				key = cubeColor(xterm256(c))
+			}
+			index, ok := palette[key]
+			if !ok {
+				index = len(palette)
+				palette[key] = index
+			}
+			indexes[y][x] = index
+		}
+	}
+
+	var out strings.Builder
+	// P2=1 keeps unpainted pixels transparent; the raster attributes declare
+	// square pixels and the image size.
+	fmt.Fprintf(&out, "\x1bP0;1;0q\"1;1;%d;%d", bounds.Dx(), bounds.Dy())
+	for c, index := range palette {
+		fmt.Fprintf(&out, "#%d;2;%d;%d;%d", index, int(c.R)*100/255, int(c.G)*100/255, int(c.B)*100/255)
+	}
+	for band := 0; band < len(indexes); band += 6 {
+		for index := 0; index < len(palette); index++ {
+			var row strings.Builder
+			used := false
+			for x := 0; x < bounds.Dx(); x++ {
+				bits := 0
+				for i := 0; i < 6 && band+i < len(indexes); i++ {
+					if indexes[band+i][x] == index {
+						bits |= 1 << i
+					}
+				}
+				used = used || bits != 0
+				row.WriteByte(byte(63 + bits))
+			}
+			if used {
+				fmt.Fprintf(&out, "#%d%s$", index, strings.TrimRight(row.String(), "?"))
+			}
+		}
+		out.WriteString("-")
+	}
+	out.WriteString("\x1b\\\n")
+	return out.String()
+}
+
+func countColors(img image.Image) int {
+	bounds := img.Bounds()
+	seen := map[color.RGBA]bool{}
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			r, g, b := rgb(img.At(x, y))
+			seen[color.RGBA{R: r, G: g, B: b}] = true
+		}
+	}
+	return len(seen)
+}
+
+// cubeColor returns the color of an xterm 256 palette entry from the color
+// cube or grayscale ramp.
+func cubeColor(index int) color.RGBA {
+	if index >= 232 {
+		v := uint8(8 + (index-232)*10)
+		return color.RGBA{R: v, G: v, B: v, A: 0xff}
+	}
+	index -= 16
+	level := func(n int) uint8 {
+		return uint8(n * 255 / 5)
+	}
+	return color.RGBA{R: level(index / 36), G: level(index / 6 % 6), B: level(index % 6), A: 0xff}
+}
+
+// resize scales img to width pixels, keeping its aspect ratio, using nearest
+// neighbour sampling so pixel art stays crisp.
+func resize(img image.Image, width int) image.Image {
+	bounds := img.Bounds()
+	if bounds.Empty() || width < 1 {
+		return img
+	}
+	height := max(1, bounds.Dy()*width/bounds.Dx())
+	resized := image.NewNRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			resized.Set(x, y, img.At(bounds.Min.X+x*bounds.Dx()/width, bounds.Min.Y+y*bounds.Dy()/height))
+		}
+	}
+	return resized
+}
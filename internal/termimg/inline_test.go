@@ -0,0 +1,65 @@
+package termimg
+
+import (
+	"image"
+	"strings"
+	"testing"
+)
+
+func TestKittyChunks(t *testing.T) {
+	// Noise doesn't compress, keeping the PNG large enough to need several
+	// chunks.
+	img := image.NewNRGBA(image.Rect(0, 0, 64, 64))
+	seed := uint32(1)
+	for i := range img.Pix {
+		seed = seed*1664525 + 1013904223
+		img.Pix[i] = uint8(seed >> 24)
+	}
+	out, err := Inline(img, Kitty, 20)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.HasPrefix(out, "\x1b_Gf=100,a=T,c=20,m=1;") {
+		t.Errorf("expected first chunk to declare more data, got %q", out[:min(40, len(out))])
+	}
+	if !strings.Contains(out, "\x1b_Gm=0;") {
+		t.Error("expected a final chunk with m=0")
+	}
+}
+
+func TestSixel(t *testing.T) {
+	out := sixel(Crop(testImage()))
+	if !strings.HasPrefix(out, "\x1bP0;1;0q\"1;1;2;2") {
+		t.Errorf("unexpected sixel header in %q", out)
+	}
+	if !strings.Contains(out, "#0;2;100;0;0") {
+		t.Errorf("expected red to be defined in the palette, got %q", out)
+	}
+	// Two red rows set the lowest two bits of each sixel: 63 + 3 = 'B'.
+	if !strings.Contains(out, "#0BB$") {
+		t.Errorf("expected two red columns, got %q", out)
+	}
+}
+
+func TestSixelWidth(t *testing.T) {
+	img := image.NewNRGBA(image.Rect(0, 0, 4, 2))
+	for i := range img.Pix {
+		img.Pix[i] = 0xff
+	}
+	cases := []struct {
+		columns  int
+		expected string
+	}{
+		{1, "\"1;1;8;4"},
+		{4, "\"1;1;32;16"},
+	}
+	for _, c := range cases {
+		out, err := Inline(img, Sixel, c.columns)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !strings.HasPrefix(out, "\x1bP0;1;0q"+c.expected) {
+			t.Errorf("%d columns: expected a %v image, got %q", c.columns, c.expected, out[:min(30, len(out))])
+		}
+	}
+}
@@ -0,0 +1,201 @@
+// Package termimg renders small images, such as pokemon sprites, as text that
+// can be printed to a terminal.
+package termimg
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"os"
+	"strings"
+)
+
+type Mode int
+
+const (
+	// TrueColor draws two pixels per cell with half blocks and 24-bit color.
+	TrueColor Mode = iota
+	// Color256 draws half blocks using the xterm 256 color palette.
+	Color256
+	// ASCII draws one character per two pixels using a brightness ramp.
+	ASCII
+)
+
+// asciiRamp is ordered from darkest to brightest.
+const asciiRamp = "@%#*+=-:. "
+
+// alphaThreshold is the alpha below which a pixel is treated as transparent.
+const alphaThreshold = 0x8000
+
+const reset = "\x1b[0m"
+
+// ParseMode converts a mode name as typed by the user into a Mode.
+func ParseMode(name string) (Mode, error) {
+	switch name {
+	case "truecolor", "24bit":
+		return TrueColor, nil
+	case "256", "256color":
+		return Color256, nil
+	case "ascii":
+		return ASCII, nil
+	}
+	return 0, fmt.Errorf("unknown render mode %q, expected truecolor, 256 or ascii", name)
+}
+
+// DetectMode picks the richest mode the terminal advertises support for.
+func DetectMode() Mode {
+	colorTerm := os.Getenv("COLORTERM")
+	if colorTerm == "truecolor" || colorTerm == "24bit" {
+		return TrueColor
+	}
+	if strings.Contains(os.Getenv("TERM"), "256color") {
+		return Color256
+	}
+	return ASCII
+}
+
+// Crop trims fully transparent rows and columns from the edges of img.
+func Crop(img image.Image) image.Image {
+	bounds := img.Bounds()
+	trimmed := image.Rectangle{Min: bounds.Max, Max: bounds.Min}
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			if _, _, _, a := img.At(x, y).RGBA(); a < alphaThreshold {
+				continue
+			}
+			trimmed.Min.X = min(trimmed.Min.X, x)
+			trimmed.Min.Y = min(trimmed.Min.Y, y)
+			trimmed.Max.X = max(trimmed.Max.X, x+1)
+			trimmed.Max.Y = max(trimmed.Max.Y, y+1)
+		}
+	}
+	if trimmed.Empty() {
+		return img
+	}
+	if sub, ok := img.(interface {
+		SubImage(r image.Rectangle) image.Image
+	}); ok {
+		return sub.SubImage(trimmed)
+	}
+	return img
+}
+
+// Render draws img scaled down to at most width columns. A width of zero or
+// less renders the image at its natural size.
+func Render(img image.Image, mode Mode, width int) string {
+	bounds := img.Bounds()
+	scale := 1.0
+	if width > 0 && bounds.Dx() > width {
+		scale = float64(bounds.Dx()) / float64(width)
+	}
+	cols := int(float64(bounds.Dx()) / scale)
+	rows := int(float64(bounds.Dy()) / scale)
+	at := func(x, y int) color.Color {
+		return img.At(bounds.Min.X+int(float64(x)*scale), bounds.Min.Y+int(float64(y)*scale))
+	}
+
+	var out strings.Builder
+	for y := 0; y < rows; y += 2 {
+		for x := 0; x < cols; x++ {
+			top := at(x, y)
+			var bottom color.Color = color.Transparent
+			if y+1 < rows {
+				bottom = at(x, y+1)
+			}
+			out.WriteString(cell(mode, top, bottom))
+		}
+		if mode != ASCII {
+			out.WriteString(reset)
+		}
+		out.WriteString("\n")
+	}
+	return out.String()
+}
+
+// cell renders one character covering a top and bottom pixel.
+func cell(mode Mode, top, bottom color.Color) string {
+	topVisible, bottomVisible := visible(top), visible(bottom)
+	if mode == ASCII {
+		switch {
+		case topVisible && bottomVisible:
+			return string(asciiRamp[rampIndex(average(top, bottom))])
+		case topVisible:
+			return string(asciiRamp[rampIndex(top)])
+		case bottomVisible:
+			return string(asciiRamp[rampIndex(bottom)])
+		}
+		return " "
+	}
+
+	switch {
+	case topVisible && bottomVisible:
+		return fg(mode, top) + bg(mode, bottom) + "▀"
+	case topVisible:
+		return reset + fg(mode, top) + "▀"
+	case bottomVisible:
+		return reset + fg(mode, bottom) + "▄"
+	}
+	return reset + " "
+}
+
+func visible(c color.Color) bool {
+	_, _, _, a := c.RGBA()
+	return a >= alphaThreshold
+}
+
+func rgb(c color.Color) (uint8, uint8, uint8) {
+	r, g, b, _ := color.NRGBAModel.Convert(c).RGBA()
+	return uint8(r >> 8), uint8(g >> 8), uint8(b >> 8)
+}
+
+func average(a, b color.Color) color.Color {
+	ar, ag, ab := rgb(a)
+	br, bg, bb := rgb(b)
+	return color.RGBA{
+		R: uint8((int(ar) + int(br)) / 2),
+		G: uint8((int(ag) + int(bg)) / 2),
+		B: uint8((int(ab) + int(bb)) / 2),
+		A: 0xff,
+	}
+}
+
+func rampIndex(c color.Color) int {
+	r, g, b := rgb(c)
+	luminance := 0.2126*float64(r) + 0.7152*float64(g) + 0.0722*float64(b)
+	return int(luminance / 256 * float64(len(asciiRamp)))
+}
+
+func fg(mode Mode, c color.Color) string {
+	if mode == Color256 {
+		return fmt.Sprintf("\x1b[38;5;%dm", xterm256(c))
+	}
+	r, g, b := rgb(c)
+	return fmt.Sprintf("\x1b[38;2;%d;%d;%dm", r, g, b)
+}
+
+func bg(mode Mode, c color.Color) string {
+	if mode == Color256 {
+		return fmt.Sprintf("\x1b[48;5;%dm", xterm256(c))
+	}
+	r, g, b := rgb(c)
+	return fmt.Sprintf("\x1b[48;2;%d;%d;%dm", r, g, b)
+}
+
+// xterm256 maps a color to the nearest entry of the 6x6x6 color cube or the
+// grayscale ramp of the xterm 256 color palette.
+func xterm256(c color.Color) int {
+	r, g, b := rgb(c)
+	if r == g && g == b {
+		if r < 8 {
+			return 16
+		}
+		if r > 248 {
+			return 231
+		}
+		return 232 + int(r-8)*24/241
+	}
+	level := func(v uint8) int {
+		return (int(v)*5 + 127) / 255
+	}
+	return 16 + 36*level(r) + 6*level(g) + level(b)
+}
@@ -0,0 +1,69 @@
+package termimg
+
+import (
+	"image"
+	"image/color"
+	"strings"
+	"testing"
+)
+
+func testImage() *image.NRGBA {
+	// A 4x4 transparent image with a 2x2 red square in the middle.
+	img := image.NewNRGBA(image.Rect(0, 0, 4, 4))
+	for y := 1; y < 3; y++ {
+		for x := 1; x < 3; x++ {
+			img.Set(x, y, color.NRGBA{R: 255, A: 255})
+		}
+	}
+	return img
+}
+
+func TestCrop(t *testing.T) {
+	cropped := Crop(testImage())
+	if cropped.Bounds() != image.Rect(1, 1, 3, 3) {
+		t.Errorf("expected bounds (1,1)-(3,3), got %v", cropped.Bounds())
+	}
+}
+
+func TestRender(t *testing.T) {
+	cases := []struct {
+		mode     Mode
+		expected string
+	}{
+		{
+			mode:     TrueColor,
+			expected: "\x1b[38;2;255;0;0m\x1b[48;2;255;0;0m▀",
+		},
+		{
+			mode:     Color256,
+			expected: "\x1b[38;5;196m\x1b[48;5;196m▀",
+		},
+		{
+			mode:     ASCII,
+			expected: "##\n",
+		},
+	}
+	for _, c := range cases {
+		out := Render(Crop(testImage()), c.mode, 0)
+		if !strings.Contains(out, c.expected) {
+			t.Errorf("Render in mode %v returned %q, expected it to contain %q", c.mode, out, c.expected)
+		}
+		if lines := strings.Count(out, "\n"); lines != 1 {
+			t.Errorf("Render in mode %v returned %d lines, expected 1", c.mode, lines)
+		}
+	}
+}
+
+func TestRenderScalesToWidth(t *testing.T) {
+	img := image.NewNRGBA(image.Rect(0, 0, 8, 8))
+	for y := 0; y < 8; y++ {
+		for x := 0; x < 8; x++ {
+			img.Set(x, y, color.White)
+		}
+	}
+	out := Render(img, ASCII, 4)
+	lines := strings.Split(strings.TrimSuffix(out, "\n"), "\n")
+	if len(lines) != 2 || len(lines[0]) != 4 {
+		t.Errorf("expected 2 lines of 4 columns, got %q", out)
+	}
+}
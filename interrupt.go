@@ -0,0 +1,54 @@
+package main
+
+import (
+	"context"
+	"sync"
+)
+
+// interruptHandler decides what a Ctrl-C means to the REPL. While a command
+// is running it cancels that command's context and the REPL returns to the
+// prompt. At an idle prompt the first press only warns, and a second press in
+// a row exits.
+type interruptHandler struct {
+	mu      sync.Mutex
+	cancel  context.CancelFunc
+	pending bool
+}
+
+// start returns the context for the next command and forgets any earlier
+// Ctrl-C at the prompt.
+func (h *interruptHandler) start() context.Context {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	ctx, cancel := context.WithCancel(context.Background())
+	h.cancel = cancel
+	h.pending = false
+	return ctx
+}
+
+// finish releases the context handed out by start.
+func (h *interruptHandler) finish() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.cancel != nil {
+		h.cancel()
+		h.cancel = nil
+	}
+}
+
+// interrupt handles a Ctrl-C. It reports whether a running command was
+// cancelled, and whether the REPL should exit.
+func (h *interruptHandler) interrupt() (cancelled, exit bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.cancel != nil {
+		h.cancel()
+		h.cancel = nil
+		return true, false
+	}
+	if h.pending {
+		return false, true
+	}
+	h.pending = true
+	return false, false
+}
@@ -0,0 +1,28 @@
+package main
+
+import "testing"
+
+func TestInterruptHandler(t *testing.T) {
+	var h interruptHandler
+
+	ctx := h.start()
+	if cancelled, exit := h.interrupt(); !cancelled || exit {
+		t.Errorf("expected a running command to be cancelled, got cancelled=%v exit=%v", cancelled, exit)
+	}
+	if ctx.Err() == nil {
+		t.Error("expected the command's context to be cancelled")
+	}
+	h.finish()
+
+	if cancelled, exit := h.interrupt(); cancelled || exit {
+		t.Errorf("expected the first press at the prompt to only warn, got cancelled=%v exit=%v", cancelled, exit)
+	}
+	h.start()
+	h.finish()
+	if _, exit := h.interrupt(); exit {
+		t.Error("expected running a command to reset the pending press")
+	}
+	if _, exit := h.interrupt(); !exit {
+		t.Error("expected a second press in a row to exit")
+	}
+}
@@ -0,0 +1,266 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/zorahscope/pokedexcli/internal/pokeapi"
+)
+
+// localizer translates pokemon and location area slugs into names in the
+// session's language. Every translation it learns is remembered both ways, so
+// a name shown in the user's language can be typed back as input.
+type localizer struct {
+	language string
+	names    map[string]string
+	slugs    map[string]string
+}
+
+// learn records that slug is called name in the current language.
+func (l *localizer) learn(slug, name string) {
+	if l.names == nil {
+		l.names = make(map[string]string)
+		l.slugs = make(map[string]string)
+	}
+	l.names[slug] = name
+	l.slugs[nameKey(name)] = slug
+}
+
+// nameKey is how translated names are looked up, ignoring case and spacing.
+func nameKey(name string) string {
+	return strings.Join(strings.Fields(strings.ToLower(name)), " ")
+}
+
+// known reports whether slug has been translated already.
+func (l *localizer) known(slug string) bool {
+	_, ok := l.names[slug]
+	return ok
+}
+
+// display returns the translated name of slug followed by the slug, or just
+// the slug when it hasn't been translated.
+func (l *localizer) display(slug string) string {
+	name, ok := l.names[slug]
+	if !ok || strings.EqualFold(name, slug) {
+		return slug
+	}
+	return fmt.Sprintf("%v (%v)", name, slug)
+}
+
+// allNames is the nameArgs of commands whose every argument may be a name.
+const allNames = -1
+
+// slugArgs maps the first n names in args back to their slugs, or every name
+// when n is allNames. Translated names can be several words, such as
+// "Canalave City", so the longest run of words before the next flag that
+// makes up a name is taken. Words that aren't part of a name count as a name
+// of their own, and are lowercased for commands that otherwise take their
+// arguments as typed.
+func (l *localizer) slugArgs(args []string, n int) []string {
+	mapped := make([]string, 0, len(args))
+	names := 0
+	for i := 0; i < len(args); {
+		if strings.HasPrefix(args[i], "--") || (n != allNames && names >= n) {
+			mapped = append(mapped, args[i])
+			i++
+			continue
+		}
+		end := i
+		for end < len(args) && !strings.HasPrefix(args[end], "--") {
+			end++
+		}
+		slug, next := strings.ToLower(args[i]), i+1
+		for j := end; j > i; j-- {
+			if s, ok := l.slugs[nameKey(strings.Join(args[i:j], " "))]; ok {
+				slug, next = s, j
+				break
+			}
+		}
+		mapped = append(mapped, slug)
+		names++
+		i = next
+	}
+	return mapped
+}
+
+// reset switches to language and forgets the translations learned so far.
+func (l *localizer) reset(language string) {
+	*l = localizer{language: language}
+}
+
+// language returns the session's language, or defaultLanguage when none is
+// set.
+func (config *commandConfig) language() string {
+	if config.locale.language == "" {
+		return defaultLanguage
+	}
+	return config.locale.language
+}
+
+// ownedName is displayName with the species name translated.
+func (l *localizer) ownedName(p ownedPokemon) string {
+	if p.Nickname == "" {
+		return l.display(p.Pokemon.Name)
+	}
+	return fmt.Sprintf("%v (%v)", p.Nickname, l.display(p.Pokemon.Name))
+}
+
+// localName returns the entry of names in language.
+func localName(names []pokeapi.Name, language string) (string, bool) {
+	for _, name := range names {
+		if name.Language.Name == language {
+			return name.Name, true
+		}
+	}
+	return "", false
+}
+
+// localizePokemon learns the names of the given pokemon from their species.
+// It does nothing when no language is set.
+func (l *localizer) localizePokemon(ctx context.Context, names []string) error {
+	if l.language == "" {
+		return nil
+	}
+	urls := []string{}
+	for _, name := range names {
+		if !l.known(name) {
+			urls = append(urls, pokeapi.BaseURL+"pokemon/"+name)
+		}
+	}
+	pokemon, err := pokeapi.GetAll[pokeapi.Pokemon](ctx, pokeapi.DefaultClient, urls)
+	if err != nil {
+		return err
+	}
+	return l.localizeSpecies(ctx, pokemon)
+}
+
+// localizeSpecies learns the names of pokemon that have already been fetched.
+func (l *localizer) localizeSpecies(ctx context.Context, pokemon []pokeapi.Pokemon) error {
+	if l.language == "" {
+		return nil
+	}
+	slugs, urls := []string{}, []string{}
+	for _, pkmn := range pokemon {
+		if !l.known(pkmn.Name) {
+			slugs = append(slugs, pkmn.Name)
+			urls = append(urls, pkmn.Species.URL)
+		}
+	}
+	return l.learnSpecies(ctx, slugs, urls)
+}
+
+// localizeSpeciesNames learns the names of the given species, such as the
+// ones seen and caught are tracked by.
+func (l *localizer) localizeSpeciesNames(ctx context.Context, names []string) error {
+	if l.language == "" {
+		return nil
+	}
+	slugs, urls := []string{}, []string{}
+	for _, name := range names {
+		if !l.known(name) {
+			slugs = append(slugs, name)
+			urls = append(urls, pokeapi.BaseURL+"pokemon-species/"+name)
+		}
+	}
+	return l.learnSpecies(ctx, slugs, urls)
+}
+
+// learnSpecies fetches the species at urls and learns their names for the
+// matching slugs.
+func (l *localizer) learnSpecies(ctx context.Context, slugs, urls []string) error {
+	species, err := pokeapi.GetAll[pokeapi.PokemonSpecies](ctx, pokeapi.DefaultClient, urls)
+	if err != nil {
+		return err
+	}
+	for i, s := range species {
+		if name, ok := localName(s.Names, l.language); ok {
+			l.learn(slugs[i], name)
+		}
+	}
+	return nil
+}
+
+// localizeAreas learns the names of the given location areas. Areas that
+// aren't named in the language use the name of their location instead.
+func (l *localizer) localizeAreas(ctx context.Context, names []string) error {
+	if l.language == "" {
+		return nil
+	}
+	urls := []string{}
+	for _, name := range names {
+		if !l.known(name) {
+			urls = append(urls, pokeapi.BaseURL+"location-area/"+name)
+		}
+	}
+	areas, err := pokeapi.GetAll[pokeapi.LocationArea](ctx, pokeapi.DefaultClient, urls)
+	if err != nil {
+		return err
+	}
+	locationURLs := []string{}
+	unnamed := []string{}
+	for _, area := range areas {
+		if name, ok := localName(area.Names, l.language); ok {
+			l.learn(area.Name, name)
+			continue
+		}
+		locationURLs = append(locationURLs, area.Location.URL)
+		unnamed = append(unnamed, area.Name)
+	}
+	locations, err := pokeapi.GetAll[pokeapi.Location](ctx, pokeapi.DefaultClient, locationURLs)
+	if err != nil {
+		return err
+	}
+	for i, location := range locations {
+		if name, ok := localName(location.Names, l.language); ok {
+			l.learn(unnamed[i], name)
+		}
+	}
+	return nil
+}
+
+// findLanguage looks up a language by its code, ignoring case since input is
+// lowercased but codes such as ja-Hrkt aren't. It returns the known codes when
+// there's no match.
+func findLanguage(ctx context.Context, code string) (string, []string, error) {
+	list, err := pokeapi.GetFromAPI[pokeapi.NamedResourceList](ctx, pokeapi.BaseURL+"language/?limit=100")
+	if err != nil {
+		return "", nil, err
+	}
+	codes := make([]string, 0, len(list.Results))
+	for _, result := range list.Results {
+		if strings.EqualFold(result.Name, code) {
+			return result.Name, nil, nil
+		}
+		codes = append(codes, result.Name)
+	}
+	return "", codes, nil
+}
+
+func commandLanguage(ctx context.Context, config *commandConfig, args []string) error {
+	if len(args) == 0 {
+		if config.locale.language == "" {
+			fmt.Println("No language set, names are shown as they're used by the API")
+			return nil
+		}
+		fmt.Printf("Showing names in %v\n", config.locale.language)
+		return nil
+	}
+	if args[0] == "clear" {
+		config.locale.reset("")
+		fmt.Println("Language cleared, names are shown as they're used by the API")
+		return nil
+	}
+	language, codes, err := findLanguage(ctx, args[0])
+	if err != nil {
+		fmt.Printf("error getting data from API: %v\n", err)
+		return fmt.Errorf("error getting data from API: %w", err)
+	}
+	if language == "" {
+		fmt.Printf("%v isn't a known language, expected one of %v\n", args[0], strings.Join(codes, ", "))
+		return nil
+	}
+	config.locale.reset(language)
+	fmt.Printf("Showing names in %v\n", language)
+	return nil
+}
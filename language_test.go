@@ -0,0 +1,90 @@
+package main
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestLocalizer(t *testing.T) {
+	var l localizer
+	l.reset("de")
+	l.learn("bulbasaur", "Bisasam")
+	l.learn("pikachu", "Pikachu")
+
+	if got := l.display("bulbasaur"); got != "Bisasam (bulbasaur)" {
+		t.Errorf("expected the translated name with its slug, got %q", got)
+	}
+	if got := l.display("pikachu"); got != "pikachu" {
+		t.Errorf("expected names matching their slug to be shown once, got %q", got)
+	}
+	if got := l.display("charmander"); got != "charmander" {
+		t.Errorf("expected untranslated names to fall back to the slug, got %q", got)
+	}
+
+	args := l.slugArgs([]string{"bisasam", "--shiny", "glumanda"}, allNames)
+	if args[0] != "bulbasaur" || args[1] != "--shiny" || args[2] != "glumanda" {
+		t.Errorf("unexpected slugs %v", args)
+	}
+
+	l.reset("fr")
+	if l.known("bulbasaur") {
+		t.Error("expected switching language to forget earlier translations")
+	}
+}
+
+func TestSlugArgs(t *testing.T) {
+	var l localizer
+	l.reset("de")
+	l.learn("bulbasaur", "Bisasam")
+	l.learn("canalave-city-area", "Kanalvira  City")
+	l.learn("mr-mime", "Pantimos")
+
+	cases := []struct {
+		args     []string
+		n        int
+		expected []string
+	}{
+		{[]string{"kanalvira", "city"}, 1, []string{"canalave-city-area"}},
+		{[]string{"kanalvira", "city", "--version", "diamond"}, 1, []string{"canalave-city-area", "--version", "diamond"}},
+		{[]string{"--version=diamond", "kanalvira", "city"}, 1, []string{"--version=diamond", "canalave-city-area"}},
+		{[]string{"kanalvira"}, 1, []string{"kanalvira"}},
+		{[]string{"bisasam", "pantimos"}, 1, []string{"bulbasaur", "pantimos"}},
+		{[]string{"pidgey", "bisasam"}, 1, []string{"pidgey", "bisasam"}},
+		{[]string{"bisasam", "pidgey", "pantimos"}, allNames, []string{"bulbasaur", "pidgey", "mr-mime"}},
+	}
+	for _, c := range cases {
+		got := l.slugArgs(c.args, c.n)
+		if strings.Join(got, "|") != strings.Join(c.expected, "|") {
+			t.Errorf("slugArgs(%v, %d) = %v, want %v", c.args, c.n, got, c.expected)
+		}
+	}
+}
+
+func TestLocalizedPokedexMissing(t *testing.T) {
+	serveAPI(t, map[string]string{
+		"pokedex/test":             testPokedex,
+		"pokemon-species/pikachu":  `{"name": "pikachu", "names": [{"name": "Pikachu", "language": {"name": "de"}}]}`,
+		"pokemon-species/giratina": `{"name": "giratina", "names": [{"name": "Giratina", "language": {"name": "de"}}]}`,
+		"pokemon-species/shaymin":  `{"name": "shaymin", "names": [{"name": "Shaymin", "language": {"name": "de"}}]}`,
+	})
+	var config commandConfig
+	config.locale.reset("de")
+	config.locale.learn("shaymin", "Shaymin-Land")
+
+	output := captureOutput(func() { pokedexMissing(context.Background(), &config, "test") })
+	if !strings.Contains(output, "  - #003 Shaymin-Land (shaymin)\n") {
+		t.Errorf("expected translated names, got:\n%v", output)
+	}
+
+	config.locale.reset("fr")
+	serveAPI(t, map[string]string{"pokedex/test": testPokedex})
+	output = captureOutput(func() {
+		if err := pokedexMissing(context.Background(), &config, "test"); err == nil {
+			t.Error("expected an error when names can't be translated")
+		}
+	})
+	if !strings.HasPrefix(output, "error getting data from API: ") {
+		t.Errorf("expected the error to be printed, got:\n%v", output)
+	}
+}
@@ -1,5 +1,34 @@
 package main
 
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/zorahscope/pokedexcli/internal/configfile"
+)
+
 func main() {
-	startRepl()
+	_, flags := parseFlags(os.Args[1:], "profile")
+	config := commandConfig{}
+	profile := flags.get("profile", os.Getenv(configfile.EnvPrefix+"PROFILE"))
+	for _, err := range loadSettings(context.Background(), &config, profile) {
+		fmt.Fprintf(os.Stderr, "config: %v\n", err)
+	}
+	if err := loadLastTrainer(context.Background(), &config); err != nil {
+		fmt.Fprintf(os.Stderr, "trainer: %v\n", err)
+	}
+
+	if flags.has("tui") {
+		err := runTUI(context.Background(), &config)
+		if saveErr := config.saveTrainer(); saveErr != nil {
+			fmt.Fprintln(os.Stderr, saveErr)
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "tui: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+	startRepl(&config)
 }
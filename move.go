@@ -0,0 +1,119 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/zorahscope/pokedexcli/internal/pokeapi"
+)
+
+// learnedByWidth is how wide the list of pokemon that learn a move is
+// wrapped to.
+const learnedByWidth = 100
+
+func commandMove(ctx context.Context, config *commandConfig, args []string) error {
+	if len(args) == 0 {
+		fmt.Println("No move selected! Please try again")
+		return nil
+	}
+	move, err := pokeapi.GetFromAPI[pokeapi.Move](ctx, pokeapi.BaseURL+"move/"+args[0])
+	if errors.Is(err, pokeapi.ErrNotFound) {
+		fmt.Printf("%v is not a move\n", args[0])
+		return nil
+	}
+	if err != nil {
+		fmt.Printf("error getting data from API: %v\n", err)
+		return fmt.Errorf("error getting data from API: %w", err)
+	}
+
+	var output strings.Builder
+	output.WriteString(fmt.Sprintf("Name: %v\n", move.Name))
+	output.WriteString(fmt.Sprintf("Type: %v\n", move.Type.Name))
+	output.WriteString(fmt.Sprintf("Damage class: %v\n", move.DamageClass.Name))
+	output.WriteString(fmt.Sprintf("Power: %v\n", optionalStat(move.Power, "")))
+	output.WriteString(fmt.Sprintf("Accuracy: %v\n", optionalStat(move.Accuracy, "%")))
+	output.WriteString(fmt.Sprintf("PP: %v\n", optionalStat(move.PP, "")))
+	output.WriteString(fmt.Sprintf("Priority: %+d\n", move.Priority))
+	output.WriteString(fmt.Sprintf("Effect: %v\n", moveEffect(move, config.language())))
+	writeLearnedBy(&output, move)
+	fmt.Print(output.String())
+	return nil
+}
+
+// optionalStat formats a move stat the API leaves out for some moves, such
+// as the power of status moves or the accuracy of moves that never miss.
+func optionalStat(value *int, unit string) string {
+	if value == nil {
+		return "-"
+	}
+	return strconv.Itoa(*value) + unit
+}
+
+// moveEffect returns the move's short effect in language, falling back to
+// English, with its effect chance filled in.
+func moveEffect(move pokeapi.Move, language string) string {
+	effect := ""
+	for _, entry := range move.EffectEntries {
+		if entry.Language.Name == language || effect == "" && entry.Language.Name == defaultLanguage {
+			effect = entry.ShortEffect
+		}
+	}
+	if effect == "" {
+		return "<no description>"
+	}
+	if move.EffectChance != nil {
+		effect = strings.ReplaceAll(effect, "$effect_chance", strconv.Itoa(*move.EffectChance))
+	}
+	return strings.Join(strings.Fields(effect), " ")
+}
+
+// writeLearnedBy writes every pokemon that can learn the move, wrapped to
+// learnedByWidth.
+func writeLearnedBy(output *strings.Builder, move pokeapi.Move) {
+	output.WriteString(fmt.Sprintf("Learned by (%d):\n", len(move.LearnedByPokemon)))
+	if len(move.LearnedByPokemon) == 0 {
+		output.WriteString("  - <none>\n")
+		return
+	}
+	line := " "
+	for i, pkmn := range move.LearnedByPokemon {
+		name := " " + pkmn.Name
+		if i < len(move.LearnedByPokemon)-1 {
+			name += ","
+		}
+		if len(line)+len(name) > learnedByWidth {
+			output.WriteString(line + "\n")
+			line = " "
+		}
+		line += name
+	}
+	output.WriteString(line + "\n")
+}
+
+// commandLearnset lists the moves any pokemon, caught or not, learns in a
+// version group.
+func commandLearnset(ctx context.Context, config *commandConfig, args []string) error {
+	args, flags := parseFlags(args, "version-group")
+	if len(args) == 0 {
+		fmt.Println("No pokemon selected! Please try again")
+		return nil
+	}
+	pkmn, err := lookupPokemon(ctx, config, args[0])
+	if errors.Is(err, pokeapi.ErrNotFound) {
+		fmt.Println(err)
+		return nil
+	}
+	if err != nil {
+		fmt.Println(err)
+		return err
+	}
+
+	var output strings.Builder
+	output.WriteString(fmt.Sprintf("Name: %v\n", pkmn.Name))
+	writeLearnset(&output, pkmn, flags.get("version-group", config.versionGroupName()))
+	fmt.Print(output.String())
+	return nil
+}
@@ -0,0 +1,77 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/zorahscope/pokedexcli/internal/pokeapi"
+)
+
+func TestMoveEffect(t *testing.T) {
+	chance := 10
+	move := pokeapi.Move{
+		EffectChance: &chance,
+		EffectEntries: []pokeapi.VerboseEffect{
+			{ShortEffect: "Has a $effect_chance% chance to\nparalyze the target.", Language: pokeapi.Result{Name: "en"}},
+		},
+	}
+	if got := moveEffect(move, "de"); got != "Has a 10% chance to paralyze the target." {
+		t.Errorf("unexpected effect %q", got)
+	}
+	move.EffectEntries = append(move.EffectEntries, pokeapi.VerboseEffect{ShortEffect: "Kann paralysieren.", Language: pokeapi.Result{Name: "de"}})
+	if got := moveEffect(move, "de"); got != "Kann paralysieren." {
+		t.Errorf("expected the German effect, got %q", got)
+	}
+	if got := optionalStat(nil, "%"); got != "-" {
+		t.Errorf("expected - for a missing stat, got %q", got)
+	}
+}
+
+func TestWriteLearnsetOrdersMethods(t *testing.T) {
+	var pkmn pokeapi.Pokemon
+	learn := func(move, method string, level int) {
+		pkmn.Moves = append(pkmn.Moves, struct {
+			Move                pokeapi.Ref[pokeapi.Move] `json:"move"`
+			VersionGroupDetails []struct {
+				LevelLearnedAt  int                                  `json:"level_learned_at"`
+				MoveLearnMethod pokeapi.Ref[pokeapi.MoveLearnMethod] `json:"move_learn_method"`
+				VersionGroup    pokeapi.Ref[pokeapi.VersionGroup]    `json:"version_group"`
+			} `json:"version_group_details"`
+		}{
+			Move: pokeapi.Ref[pokeapi.Move]{Name: move},
+			VersionGroupDetails: []struct {
+				LevelLearnedAt  int                                  `json:"level_learned_at"`
+				MoveLearnMethod pokeapi.Ref[pokeapi.MoveLearnMethod] `json:"move_learn_method"`
+				VersionGroup    pokeapi.Ref[pokeapi.VersionGroup]    `json:"version_group"`
+			}{{
+				LevelLearnedAt:  level,
+				MoveLearnMethod: pokeapi.Ref[pokeapi.MoveLearnMethod]{Name: method},
+				VersionGroup:    pokeapi.Ref[pokeapi.VersionGroup]{Name: "red-blue", URL: "https://pokeapi.co/api/v2/version-group/1/"},
+			}},
+		})
+	}
+	learn("volt-tackle", "light-ball-egg", 0)
+	learn("wish", "egg", 0)
+	learn("thunder", "tutor", 0)
+	learn("thunderbolt", "machine", 0)
+	learn("thunder-shock", "level-up", 1)
+
+	var output strings.Builder
+	writeLearnset(&output, pkmn, "red-blue")
+	got := output.String()
+	order := []string{"level-up:", "machine (TM/HM):", "egg:", "tutor:", "light-ball-egg:"}
+	last := -1
+	for _, heading := range order {
+		i := strings.Index(got, heading)
+		if i < last {
+			t.Fatalf("expected methods in the order %v, got:\n%v", order, got)
+		}
+		last = i
+	}
+
+	output.Reset()
+	writeLearnset(&output, pkmn, "sword-shield")
+	if !strings.Contains(output.String(), "Version groups with moves: red-blue") {
+		t.Errorf("expected the version groups with moves to be listed, got:\n%v", output.String())
+	}
+}
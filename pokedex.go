@@ -0,0 +1,252 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/zorahscope/pokedexcli/internal/pokeapi"
+)
+
+const defaultDex = "national"
+
+// speciesName returns the species a pokemon belongs to. Seen and caught are
+// tracked by species, as pokedexes list them, so that forms such as
+// giratina-altered count towards giratina.
+func speciesName(pkmn pokeapi.Pokemon) string {
+	if pkmn.Species.Name == "" {
+		return pkmn.Name
+	}
+	return pkmn.Species.Name
+}
+
+// markSeen marks a species as seen.
+func (config *commandConfig) markSeen(name string) {
+	if config.seen[name] {
+		return
+	}
+	if config.seen == nil {
+		config.seen = make(map[string]bool)
+	}
+	config.seen[name] = true
+	config.changed()
+}
+
+// markCaught marks a species as seen and caught.
+func (config *commandConfig) markCaught(name string) {
+	config.markSeen(name)
+	if config.caught[name] {
+		return
+	}
+	if config.caught == nil {
+		config.caught = make(map[string]bool)
+	}
+	config.caught[name] = true
+	config.changed()
+}
+
+// markSeenPokemon marks the species of the referenced pokemon as seen,
+// fetching them to find out what species they are. It's best effort: a
+// pokemon that can't be fetched is marked seen by its own name, and the
+// first such error is returned to warn about.
+func (config *commandConfig) markSeenPokemon(ctx context.Context, refs []pokeapi.Ref[pokeapi.Pokemon]) error {
+	urls := make([]string, len(refs))
+	for i, ref := range refs {
+		urls[i] = ref.URL
+	}
+	pokemon, errs := pokeapi.GetEach[pokeapi.Pokemon](ctx, pokeapi.DefaultClient, urls)
+	var failed error
+	for i, err := range errs {
+		if err != nil {
+			config.markSeen(refs[i].Name)
+			if failed == nil {
+				failed = fmt.Errorf("error getting data from API: %w", err)
+			}
+			continue
+		}
+		config.markSeen(speciesName(pokemon[i]))
+	}
+	return failed
+}
+
+// progress counts how many of the given species have been seen and caught.
+type progress struct {
+	total  int
+	seen   int
+	caught int
+}
+
+func (config *commandConfig) progressOf(species []string) progress {
+	p := progress{total: len(species)}
+	for _, name := range species {
+		if config.seen[name] {
+			p.seen++
+		}
+		if config.caught[name] {
+			p.caught++
+		}
+	}
+	return p
+}
+
+func (p progress) String() string {
+	return fmt.Sprintf("seen %d/%d (%.1f%%), caught %d/%d (%.1f%%)",
+		p.seen, p.total, percent(p.seen, p.total),
+		p.caught, p.total, percent(p.caught, p.total))
+}
+
+func percent(part, total int) float64 {
+	if total == 0 {
+		return 0
+	}
+	return 100 * float64(part) / float64(total)
+}
+
+func commandPokedex(ctx context.Context, config *commandConfig, args []string) error {
+	if len(args) == 0 {
+		return pokedexCaught(ctx, config)
+	}
+	dex := defaultDex
+	if len(args) > 1 {
+		dex = args[1]
+	}
+	switch args[0] {
+	case "seen":
+		return pokedexSeen(ctx, config)
+	case "stats":
+		return pokedexStats(ctx, config, dex)
+	case "missing":
+		return pokedexMissing(ctx, config, dex)
+	}
+	return pokedexQuery(ctx, config, args)
+}
+
+func pokedexCaught(ctx context.Context, config *commandConfig) error {
+	owned := config.storage.all()
+	sort.SliceStable(owned, func(i, j int) bool {
+		return owned[i].Pokemon.ID < owned[j].Pokemon.ID
+	})
+	pokemon := make([]pokeapi.Pokemon, 0, len(owned))
+	for _, o := range owned {
+		pokemon = append(pokemon, o.Pokemon)
+	}
+	if err := config.locale.localizeSpecies(ctx, pokemon); err != nil {
+		fmt.Printf("error getting data from API: %v\n", err)
+		return fmt.Errorf("error getting data from API: %w", err)
+	}
+
+	fmt.Println("Your Pokedex:")
+	listed := make(map[string]bool)
+	for _, o := range owned {
+		if listed[o.Pokemon.Name] {
+			continue
+		}
+		listed[o.Pokemon.Name] = true
+		fmt.Printf("  - #%03d %v\n", o.Pokemon.ID, config.locale.display(o.Pokemon.Name))
+	}
+	if len(listed) == 0 {
+		fmt.Println("  - <empty>")
+	}
+	fmt.Printf("Seen: %d, Caught: %d\n", len(config.seen), len(config.caught))
+	return nil
+}
+
+func pokedexSeen(ctx context.Context, config *commandConfig) error {
+	names := make([]string, 0, len(config.seen))
+	for name := range config.seen {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	if err := config.locale.localizeSpeciesNames(ctx, names); err != nil {
+		fmt.Printf("error getting data from API: %v\n", err)
+		return fmt.Errorf("error getting data from API: %w", err)
+	}
+
+	fmt.Println("Pokemon seen:")
+	if len(names) == 0 {
+		fmt.Println("  - <empty>")
+	}
+	for _, name := range names {
+		marker := ""
+		if config.caught[name] {
+			marker = " (caught)"
+		}
+		fmt.Printf("  - %v%v\n", config.locale.display(name), marker)
+	}
+	return nil
+}
+
+func pokedexStats(ctx context.Context, config *commandConfig, dex string) error {
+	pokedex, err := pokeapi.GetPokedex(ctx, dex)
+	if err != nil {
+		fmt.Printf("error getting data from API: %v\n", err)
+		return fmt.Errorf("error getting data from API: %w", err)
+	}
+	species := make([]string, 0, len(pokedex.PokemonEntries))
+	for _, entry := range pokedex.PokemonEntries {
+		species = append(species, entry.PokemonSpecies.Name)
+	}
+	fmt.Printf("Pokedex completion (%v):\n  %v\n", pokedex.Name, config.progressOf(species))
+
+	generations, err := pokeapi.GetFromAPI[pokeapi.NamedResourceList](ctx, pokeapi.BaseURL+"generation/")
+	if err != nil {
+		fmt.Printf("error getting data from API: %v\n", err)
+		return fmt.Errorf("error getting data from API: %w", err)
+	}
+	urls := make([]string, 0, len(generations.Results))
+	for _, result := range generations.Results {
+		urls = append(urls, result.URL)
+	}
+	details, err := pokeapi.GetAll[pokeapi.Generation](ctx, pokeapi.DefaultClient, urls)
+	if err != nil {
+		fmt.Printf("error getting data from API: %v\n", err)
+		return fmt.Errorf("error getting data from API: %w", err)
+	}
+	fmt.Println("By generation:")
+	for _, generation := range details {
+		species := make([]string, 0, len(generation.PokemonSpecies))
+		for _, s := range generation.PokemonSpecies {
+			species = append(species, s.Name)
+		}
+		fmt.Printf("  %-16v %v\n", generation.Name+":", config.progressOf(species))
+	}
+	return nil
+}
+
+func pokedexMissing(ctx context.Context, config *commandConfig, dex string) error {
+	pokedex, err := pokeapi.GetPokedex(ctx, dex)
+	if err != nil {
+		fmt.Printf("error getting data from API: %v\n", err)
+		return fmt.Errorf("error getting data from API: %w", err)
+	}
+	entries := pokedex.PokemonEntries
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].EntryNumber < entries[j].EntryNumber
+	})
+
+	missing := entries[:0]
+	names := []string{}
+	for _, entry := range entries {
+		if !config.caught[entry.PokemonSpecies.Name] {
+			missing = append(missing, entry)
+			names = append(names, entry.PokemonSpecies.Name)
+		}
+	}
+	if err := config.locale.localizeSpeciesNames(ctx, names); err != nil {
+		fmt.Printf("error getting data from API: %v\n", err)
+		return fmt.Errorf("error getting data from API: %w", err)
+	}
+
+	fmt.Printf("Missing from the %v pokedex:\n", pokedex.Name)
+	for _, entry := range missing {
+		marker := ""
+		if config.seen[entry.PokemonSpecies.Name] {
+			marker = " (seen)"
+		}
+		fmt.Printf("  - #%03d %v%v\n", entry.EntryNumber, config.locale.display(entry.PokemonSpecies.Name), marker)
+	}
+	if len(missing) == 0 {
+		fmt.Println("  - <none, the pokedex is complete!>")
+	}
+	return nil
+}
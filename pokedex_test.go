@@ -0,0 +1,145 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/zorahscope/pokedexcli/internal/pokeapi"
+)
+
+// serveAPI points pokeapi.BaseURL at a test server that answers each path
+// under it with the given JSON, and with a 404 otherwise.
+func serveAPI(t *testing.T, responses map[string]string) {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, ok := responses[strings.TrimPrefix(r.URL.Path, "/")]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		fmt.Fprint(w, body)
+	}))
+	baseURL := pokeapi.BaseURL
+	pokeapi.BaseURL = server.URL + "/"
+	t.Cleanup(func() {
+		pokeapi.BaseURL = baseURL
+		server.Close()
+	})
+}
+
+const testPokedex = `{"name": "test", "pokemon_entries": [
+	{"entry_number": 3, "pokemon_species": {"name": "shaymin"}},
+	{"entry_number": 1, "pokemon_species": {"name": "pikachu"}},
+	{"entry_number": 2, "pokemon_species": {"name": "giratina"}}
+]}`
+
+func TestSpeciesName(t *testing.T) {
+	pkmn := pokeapi.Pokemon{Name: "giratina-altered"}
+	pkmn.Species.Name = "giratina"
+	if got := speciesName(pkmn); got != "giratina" {
+		t.Errorf("expected giratina, got %v", got)
+	}
+	if got := speciesName(pokeapi.Pokemon{Name: "pikachu"}); got != "pikachu" {
+		t.Errorf("expected the pokemon's name without a species, got %v", got)
+	}
+}
+
+func TestProgressOf(t *testing.T) {
+	var config commandConfig
+	config.markCaught("giratina")
+	config.markSeen("pikachu")
+
+	got := config.progressOf([]string{"pikachu", "giratina", "shaymin", "mew"})
+	if got != (progress{total: 4, seen: 2, caught: 1}) {
+		t.Errorf("unexpected progress %+v", got)
+	}
+	if s := got.String(); s != "seen 2/4 (50.0%), caught 1/4 (25.0%)" {
+		t.Errorf("unexpected progress %q", s)
+	}
+	if s := (progress{}).String(); s != "seen 0/0 (0.0%), caught 0/0 (0.0%)" {
+		t.Errorf("unexpected progress %q", s)
+	}
+}
+
+func TestMarkSeenPokemonRecordsSpecies(t *testing.T) {
+	serveAPI(t, map[string]string{
+		"pokemon/giratina-altered": `{"id": 487, "name": "giratina-altered", "species": {"name": "giratina"}}`,
+		"pokemon/shaymin-land":     `{"id": 492, "name": "shaymin-land", "species": {"name": "shaymin"}}`,
+	})
+	refs := []pokeapi.Ref[pokeapi.Pokemon]{
+		{Name: "giratina-altered", URL: pokeapi.BaseURL + "pokemon/giratina-altered"},
+		{Name: "shaymin-land", URL: pokeapi.BaseURL + "pokemon/shaymin-land"},
+	}
+	var config commandConfig
+	if err := config.markSeenPokemon(context.Background(), refs); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !config.seen["giratina"] || !config.seen["shaymin"] || len(config.seen) != 2 {
+		t.Errorf("expected giratina and shaymin to be seen, got %v", config.seen)
+	}
+
+	output := captureOutput(func() { pokedexSeen(context.Background(), &config) })
+	if !strings.Contains(output, "  - giratina\n") || strings.Contains(output, "altered") {
+		t.Errorf("expected species in the seen list, got:\n%v", output)
+	}
+}
+
+func TestPokedexMissing(t *testing.T) {
+	serveAPI(t, map[string]string{"pokedex/test": testPokedex})
+	var config commandConfig
+	pkmn := pokeapi.Pokemon{Name: "giratina-altered"}
+	pkmn.Species.Name = "giratina"
+	config.markCaught(speciesName(pkmn))
+	config.markSeen("shaymin")
+
+	output := captureOutput(func() {
+		if err := pokedexMissing(context.Background(), &config, "test"); err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+	})
+	expected := "Missing from the test pokedex:\n  - #001 pikachu\n  - #003 shaymin (seen)\n"
+	if output != expected {
+		t.Errorf("expected:\n%v\ngot:\n%v", expected, output)
+	}
+
+	config.markCaught("pikachu")
+	config.markCaught("shaymin")
+	output = captureOutput(func() { pokedexMissing(context.Background(), &config, "test") })
+	if !strings.Contains(output, "<none, the pokedex is complete!>") {
+		t.Errorf("expected a complete pokedex, got:\n%v", output)
+	}
+}
+
+func TestExploreMarksSeenBestEffort(t *testing.T) {
+	responses := map[string]string{
+		"pokemon/16": `{"id": 16, "name": "pidgey", "species": {"name": "pidgey"}}`,
+	}
+	serveAPI(t, responses)
+	// The encounters link to the pokemon by id, which wormadam-plant's
+	// lookup fails on.
+	responses["location-area/route-1-area"] = `{"name": "route-1-area", "pokemon_encounters": [
+		{"pokemon": {"name": "pidgey", "url": "` + pokeapi.BaseURL + `pokemon/16"}, "version_details": [
+			{"version": {"name": "red"}, "encounter_details": [{"chance": 30, "min_level": 2, "max_level": 3, "method": {"name": "walk"}}]}
+		]},
+		{"pokemon": {"name": "wormadam-plant", "url": "` + pokeapi.BaseURL + `pokemon/413"}, "version_details": [
+			{"version": {"name": "red"}, "encounter_details": [{"chance": 5, "min_level": 4, "max_level": 4, "method": {"name": "walk"}}]}
+		]}
+	]}`
+	var config commandConfig
+
+	output := captureOutput(func() {
+		if err := commandExplore(context.Background(), &config, []string{"route-1-area"}); err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+	})
+	if !strings.Contains(output, "marked seen by form") || !strings.Contains(output, "wormadam-plant") || !strings.Contains(output, "pidgey") {
+		t.Errorf("expected a warning and the encounters, got:\n%v", output)
+	}
+	if !config.seen["pidgey"] || !config.seen["wormadam-plant"] {
+		t.Errorf("expected both pokemon to be seen, got %v", config.seen)
+	}
+}
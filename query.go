@@ -0,0 +1,305 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/zorahscope/pokedexcli/internal/pokeapi"
+)
+
+const defaultPageSize = 20
+
+// statAliases maps the short stat names accepted in queries to the stat names
+// used by the API.
+var statAliases = map[string]string{
+	"hp":              "hp",
+	"atk":             "attack",
+	"attack":          "attack",
+	"def":             "defense",
+	"defense":         "defense",
+	"spa":             "special-attack",
+	"special-attack":  "special-attack",
+	"spd":             "special-defense",
+	"special-defense": "special-defense",
+	"spe":             "speed",
+	"speed":           "speed",
+}
+
+// queryOperators is ordered so that two character operators are matched
+// before their one character prefixes.
+var queryOperators = []string{">=", "<=", "!=", ">", "<", "=", ":"}
+
+var romanNumerals = map[string]int{
+	"i": 1, "ii": 2, "iii": 3, "iv": 4, "v": 5, "vi": 6, "vii": 7, "viii": 8, "ix": 9,
+}
+
+type filter struct {
+	field string
+	op    string
+	value string
+}
+
+// collectionQuery filters, sorts and paginates the owned pokemon. It is built
+// from terms such as `type:fire`, `gen:1`, `atk>=100`, `sort:-bst` and
+// `page:2`.
+type collectionQuery struct {
+	filters []filter
+	sortKey string
+	desc    bool
+	page    int
+	limit   int
+}
+
+func parseQuery(terms []string) (collectionQuery, error) {
+	q := collectionQuery{sortKey: "id", page: 1, limit: defaultPageSize}
+	for _, term := range terms {
+		field, op, value, ok := splitTerm(term)
+		if !ok || value == "" {
+			return q, fmt.Errorf("can't understand %q, expected a term like type:fire or atk>=100", term)
+		}
+		switch field {
+		case "sort":
+			q.desc = strings.HasPrefix(value, "-")
+			q.sortKey = strings.TrimPrefix(value, "-")
+			switch q.sortKey {
+			case "id", "name", "bst", "caught":
+			default:
+				return q, fmt.Errorf("can't sort by %q, expected id, name, bst or caught", q.sortKey)
+			}
+		case "page", "limit":
+			n, err := strconv.Atoi(value)
+			if err != nil || n < 1 {
+				return q, fmt.Errorf("%v must be a positive number", field)
+			}
+			if field == "page" {
+				q.page = n
+			} else {
+				q.limit = n
+			}
+		case "type", "ability", "location", "name":
+			if op != ":" && op != "=" && op != "!=" {
+				return q, fmt.Errorf("%v only supports :, = and !=", field)
+			}
+			q.filters = append(q.filters, filter{field: field, op: op, value: value})
+		case "gen":
+			if _, err := parseGeneration(value); err != nil {
+				return q, err
+			}
+			q.filters = append(q.filters, filter{field: field, op: op, value: value})
+		default:
+			if stat, ok := statAliases[field]; ok {
+				field = stat
+			} else if field != "bst" && field != "id" {
+				return q, fmt.Errorf("unknown field %q", field)
+			}
+			if _, err := strconv.Atoi(value); err != nil {
+				return q, fmt.Errorf("%v must be compared with a number", field)
+			}
+			q.filters = append(q.filters, filter{field: field, op: op, value: value})
+		}
+	}
+	return q, nil
+}
+
+func splitTerm(term string) (field, op, value string, ok bool) {
+	index := -1
+	for _, candidate := range queryOperators {
+		i := strings.Index(term, candidate)
+		if i > 0 && (index == -1 || i < index) {
+			index, op = i, candidate
+		}
+	}
+	if index == -1 {
+		return "", "", "", false
+	}
+	return term[:index], op, term[index+len(op):], true
+}
+
+// parseGeneration accepts 1, i or generation-i.
+func parseGeneration(value string) (int, error) {
+	value = strings.TrimPrefix(value, "generation-")
+	if n, ok := romanNumerals[value]; ok {
+		return n, nil
+	}
+	if n, err := strconv.Atoi(value); err == nil && n > 0 {
+		return n, nil
+	}
+	return 0, fmt.Errorf("unknown generation %q", value)
+}
+
+// generationLookup returns the generation number a pokemon was introduced in.
+type generationLookup func(ctx context.Context, pkmn pokeapi.Pokemon) (int, error)
+
+func (q collectionQuery) matches(ctx context.Context, owned ownedPokemon, generationOf generationLookup) (bool, error) {
+	for _, f := range q.filters {
+		ok, err := f.matches(ctx, owned, generationOf)
+		if err != nil || !ok {
+			return false, err
+		}
+	}
+	return true, nil
+}
+
+func (f filter) matches(ctx context.Context, owned ownedPokemon, generationOf generationLookup) (bool, error) {
+	pkmn := owned.Pokemon
+	switch f.field {
+	case "type":
+		found := false
+		for _, t := range pkmn.Types {
+			found = found || t.Type.Name == f.value
+		}
+		return found == (f.op != "!="), nil
+	case "ability":
+		found := false
+		for _, a := range pkmn.Abilities {
+			found = found || a.Ability.Name == f.value
+		}
+		return found == (f.op != "!="), nil
+	case "location":
+		return (owned.CaughtLocation == f.value) == (f.op != "!="), nil
+	case "name":
+		// Nicknames keep their case, so they're matched ignoring it like
+		// storage.find does.
+		value := strings.ToLower(f.value)
+		found := strings.Contains(pkmn.Name, value) || strings.Contains(strings.ToLower(owned.Nickname), value)
+		return found == (f.op != "!="), nil
+	case "gen":
+		gen, err := generationOf(ctx, pkmn)
+		if err != nil {
+			return false, err
+		}
+		want, _ := parseGeneration(f.value)
+		return compare(gen, f.op, want), nil
+	case "bst":
+		want, _ := strconv.Atoi(f.value)
+		return compare(baseStatTotal(pkmn), f.op, want), nil
+	case "id":
+		want, _ := strconv.Atoi(f.value)
+		return compare(pkmn.ID, f.op, want), nil
+	}
+	want, _ := strconv.Atoi(f.value)
+	return compare(baseStat(pkmn, f.field), f.op, want), nil
+}
+
+func compare(actual int, op string, want int) bool {
+	switch op {
+	case ">=":
+		return actual >= want
+	case "<=":
+		return actual <= want
+	case ">":
+		return actual > want
+	case "<":
+		return actual < want
+	case "!=":
+		return actual != want
+	}
+	return actual == want
+}
+
+func baseStat(pkmn pokeapi.Pokemon, name string) int {
+	for _, stat := range pkmn.Stats {
+		if stat.Stat.Name == name {
+			return stat.BaseStat
+		}
+	}
+	return 0
+}
+
+func baseStatTotal(pkmn pokeapi.Pokemon) int {
+	total := 0
+	for _, stat := range pkmn.Stats {
+		total += stat.BaseStat
+	}
+	return total
+}
+
+// run filters, sorts and paginates owned, returning the requested page along
+// with the total number of pages.
+func (q collectionQuery) run(ctx context.Context, owned []ownedPokemon, generationOf generationLookup) ([]ownedPokemon, int, error) {
+	matched := []ownedPokemon{}
+	for _, o := range owned {
+		ok, err := q.matches(ctx, o, generationOf)
+		if err != nil {
+			return nil, 0, err
+		}
+		if ok {
+			matched = append(matched, o)
+		}
+	}
+
+	sort.SliceStable(matched, func(i, j int) bool {
+		a, b := matched[i], matched[j]
+		if q.desc {
+			a, b = b, a
+		}
+		switch q.sortKey {
+		case "name":
+			return a.Pokemon.Name < b.Pokemon.Name
+		case "bst":
+			return baseStatTotal(a.Pokemon) < baseStatTotal(b.Pokemon)
+		case "caught":
+			return a.CaughtAt.Before(b.CaughtAt)
+		}
+		return a.Pokemon.ID < b.Pokemon.ID
+	})
+
+	pages := (len(matched) + q.limit - 1) / q.limit
+	start := (q.page - 1) * q.limit
+	if start >= len(matched) {
+		return []ownedPokemon{}, pages, nil
+	}
+	end := min(start+q.limit, len(matched))
+	return matched[start:end], pages, nil
+}
+
+// generationFromAPI looks up the generation of a pokemon's species.
+func generationFromAPI(ctx context.Context, pkmn pokeapi.Pokemon) (int, error) {
+	species, err := pkmn.Species.Resolve(ctx, pokeapi.DefaultClient)
+	if err != nil {
+		return 0, fmt.Errorf("error getting data from API: %w", err)
+	}
+	return species.Generation.ID(), nil
+}
+
+func pokedexQuery(ctx context.Context, config *commandConfig, terms []string) error {
+	q, err := parseQuery(terms)
+	if err != nil {
+		fmt.Println(err)
+		return nil
+	}
+	results, pages, err := q.run(ctx, config.storage.all(), generationFromAPI)
+	if err != nil {
+		fmt.Println(err)
+		return err
+	}
+
+	pokemon := make([]pokeapi.Pokemon, 0, len(results))
+	for _, owned := range results {
+		pokemon = append(pokemon, owned.Pokemon)
+	}
+	if err := config.locale.localizeSpecies(ctx, pokemon); err != nil {
+		fmt.Printf("error getting data from API: %v\n", err)
+		return fmt.Errorf("error getting data from API: %w", err)
+	}
+
+	fmt.Println("Your Pokedex:")
+	if len(results) == 0 {
+		fmt.Println("  - <no matches>")
+	}
+	for _, owned := range results {
+		types := make([]string, 0, len(owned.Pokemon.Types))
+		for _, t := range owned.Pokemon.Types {
+			types = append(types, t.Type.Name)
+		}
+		fmt.Printf("  - #%03d %-24v %-18v BST %d\n", owned.Pokemon.ID, config.locale.ownedName(owned),
+			strings.Join(types, "/"), baseStatTotal(owned.Pokemon))
+	}
+	if pages > 1 {
+		fmt.Printf("Page %d of %d\n", q.page, pages)
+	}
+	return nil
+}
@@ -0,0 +1,78 @@
+package main
+
+import (
+	"context"
+	"testing"
+
+	"github.com/zorahscope/pokedexcli/internal/pokeapi"
+)
+
+func queryPokemon(id int, name, typ string, attack int) ownedPokemon {
+	pkmn := pokeapi.Pokemon{ID: id, Name: name}
+	pkmn.Types = append(pkmn.Types, struct {
+		Slot int                       `json:"slot"`
+		Type pokeapi.Ref[pokeapi.Type] `json:"type"`
+	}{})
+	pkmn.Types[0].Type.Name = typ
+	pkmn.Stats = append(pkmn.Stats, struct {
+		BaseStat int                       `json:"base_stat"`
+		Effort   int                       `json:"effort"`
+		Stat     pokeapi.Ref[pokeapi.Stat] `json:"stat"`
+	}{BaseStat: attack})
+	pkmn.Stats[0].Stat.Name = "attack"
+	return ownedPokemon{ID: id, Pokemon: pkmn}
+}
+
+func TestParseQuery(t *testing.T) {
+	cases := []struct {
+		terms   []string
+		wantErr bool
+	}{
+		{terms: []string{"type:fire", "atk>=100", "sort:-bst", "page:2"}},
+		{terms: []string{"gen:iv", "ability=blaze", "limit:5"}},
+		{terms: []string{"colour:red"}, wantErr: true},
+		{terms: []string{"sort:weight"}, wantErr: true},
+		{terms: []string{"atk>=lots"}, wantErr: true},
+		{terms: []string{"fire"}, wantErr: true},
+	}
+	for _, c := range cases {
+		_, err := parseQuery(c.terms)
+		if (err != nil) != c.wantErr {
+			t.Errorf("parseQuery(%v) error = %v, wantErr %v", c.terms, err, c.wantErr)
+		}
+	}
+}
+
+func TestQueryRun(t *testing.T) {
+	owned := []ownedPokemon{
+		queryPokemon(4, "charmander", "fire", 52),
+		queryPokemon(1, "bulbasaur", "grass", 49),
+		queryPokemon(6, "charizard", "fire", 84),
+		queryPokemon(37, "vulpix", "fire", 41),
+	}
+	q, err := parseQuery([]string{"type:fire", "atk>45", "sort:-id", "limit:1"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	results, pages, err := q.run(context.Background(), owned, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if pages != 2 {
+		t.Errorf("expected 2 pages, got %d", pages)
+	}
+	if len(results) != 1 || results[0].Pokemon.Name != "charizard" {
+		t.Errorf("expected first page to hold charizard, got %v", results)
+	}
+}
+
+func TestNameFilterIgnoresCase(t *testing.T) {
+	pikachu := queryPokemon(25, "pikachu", "electric", 55)
+	pikachu.Nickname = "Sparky"
+	for _, value := range []string{"spark", "Spark", "SPARKY", "Pika"} {
+		ok, err := filter{field: "name", op: ":", value: value}.matches(context.Background(), pikachu, nil)
+		if err != nil || !ok {
+			t.Errorf("expected name:%v to match Sparky (pikachu), got %v, %v", value, ok, err)
+		}
+	}
+}
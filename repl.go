@@ -2,25 +2,48 @@ package main
 
 import (
 	"bufio"
+	"context"
+	"errors"
 	"fmt"
 	"github.com/zorahscope/pokedexcli/internal/pokeapi"
 	"math/rand"
 	"os"
+	"os/signal"
+	"strconv"
 	"strings"
+	"time"
 )
 
 type cliCommand struct {
 	name        string
 	description string
-	callback    func(config *commandConfig, args string) error
+	callback    func(ctx context.Context, config *commandConfig, args []string) error
+	// rawArgs passes the arguments as typed instead of lowercased, for
+	// commands that take file paths or nicknames.
+	rawArgs bool
+	// nameArgs is how many pokemon or location area names the arguments
+	// start with, or allNames. Those may be typed as translated names.
+	nameArgs int
 }
 
 type commandConfig struct {
-	next       string
-	previous   string
-	pageNum    int
+	locations  *pokeapi.Paginator
 	exploreURL string
-	pokedex    map[string]pokeapi.Pokemon
+	area       string
+	version    *gameVersion
+	locale     localizer
+	options    options
+	trainer    trainerState
+	storage    storage
+	team       []ownedPokemon
+	nextID     int
+	seen       map[string]bool
+	caught     map[string]bool
+	lastUndo   *undoAction
+	input      *bufio.Scanner
+	// dirty is set when a command changes anything the trainer's save file
+	// holds, so it's saved once the command finishes.
+	dirty bool
 }
 
 var supportedCommands map[string]cliCommand
@@ -42,7 +65,7 @@ func init() {
 		},
 		"map": {
 			name:        "map",
-			description: "Displays list of location areas, each subsequent call will return the next page of location areas",
+			description: "Displays list of location areas, each subsequent call will return the next page of location areas; jump with --page <n> and change the page size with --limit <n>",
 			callback:    commandMap,
 		},
 		"mapb": {
@@ -52,24 +75,146 @@ func init() {
 		},
 		"explore": {
 			name:        "explore",
-			description: "Displays list of pokemon at given location",
+			description: "Displays the pokemon at given location with their encounter method, chance and levels for each game version; filter with --version=<name> and --method=<name>",
 			callback:    commandExplore,
+			nameArgs:    1,
+		},
+		"where": {
+			name:        "where",
+			description: "Lists the location areas a pokemon can be found in, with encounter method, chance and levels for each game version; filter with --version=<name>",
+			callback:    commandWhere,
+			nameArgs:    1,
 		},
 		"catch": {
 			name:        "catch",
 			description: "Attempts to catch designated pokemon",
 			callback:    commandCatch,
+			nameArgs:    1,
 		},
 		"inspect": {
 			name:        "inspect",
-			description: "Displays information of captured pokemon",
+			description: "Displays information of captured pokemon; add --sprite, --abilities, --moves, --items, --flavor or --all for more, with --lang, --version-group and --imperial to adjust them",
 			callback:    commandInspect,
+			nameArgs:    1,
+		},
+		"learnset": {
+			name:        "learnset",
+			description: "Lists the moves a pokemon, caught or not, learns by level-up, TM, egg and tutor; pick the game with --version-group, otherwise the session's version or the latest one is used",
+			callback:    commandLearnset,
+			nameArgs:    1,
+		},
+		"move": {
+			name:        "move",
+			description: "Looks up a move's type, power, accuracy, PP, priority, damage class, effect and the pokemon that learn it",
+			callback:    commandMove,
+		},
+		"sprite": {
+			name:        "sprite",
+			description: "Draws a pokemon's sprite; choose a variant with --gen=<i-viii|artwork>, --shiny and --back, and the output with --mode=<kitty|iterm2|sixel|truecolor|256|ascii> and --width=<columns>",
+			callback:    commandSprite,
+			nameArgs:    1,
+		},
+		"compare": {
+			name:        "compare",
+			description: "Compares two or more pokemon, caught or not, side by side: base stats with bars and totals, how their types match up against each other, and which abilities they share",
+			callback:    commandCompare,
+			nameArgs:    allNames,
 		},
 		"pokedex": {
 			name:        "pokedex",
-			description: "Displays list of pokemon that have been captured",
+			description: "Displays list of pokemon that have been captured; filter and sort with a query such as `pokedex type:fire atk>=80 sort:-bst`, or use `pokedex seen`, `pokedex stats [dex]` or `pokedex missing [dex]` for completion progress",
 			callback:    commandPokedex,
 		},
+		"release": {
+			name:        "release",
+			description: "Releases a captured pokemon back into the wild",
+			callback:    commandRelease,
+			nameArgs:    1,
+		},
+		"nickname": {
+			name:        "nickname",
+			description: "Gives a captured pokemon a nickname, or clears it when none is given",
+			callback:    commandNickname,
+			rawArgs:     true,
+			nameArgs:    1,
+		},
+		"transfer": {
+			name:        "transfer",
+			description: "Moves a captured pokemon into a PC box, the first one with room unless a box number is given",
+			callback:    commandTransfer,
+			nameArgs:    1,
+		},
+		"party": {
+			name:        "party",
+			description: "Displays the pokemon in your party",
+			callback:    commandParty,
+		},
+		"team": {
+			name:        "team",
+			description: "Builds a team of up to six pokemon, caught or not, and shows its offensive coverage from their moves and its shared weaknesses from their types; use team add, remove, moves <pokemon> [move...], party or clear",
+			callback:    commandTeam,
+			nameArgs:    allNames,
+		},
+		"box": {
+			name:        "box",
+			description: "Displays the pokemon in the given PC box, or a summary of every box",
+			callback:    commandBox,
+		},
+		"deposit": {
+			name:        "deposit",
+			description: "Moves a party pokemon into a PC box",
+			callback:    commandDeposit,
+			nameArgs:    1,
+		},
+		"withdraw": {
+			name:        "withdraw",
+			description: "Moves a pokemon from a PC box into your party",
+			callback:    commandWithdraw,
+			nameArgs:    1,
+		},
+		"tui": {
+			name:        "tui",
+			description: "Opens the full-screen interface with location, encounter, details and pokedex panes",
+			callback:    commandTUI,
+		},
+		"version": {
+			name:        "version",
+			description: "Sets the game version that explore, where and inspect are scoped to, shows it when no name is given, or clears it with `version clear`",
+			callback:    commandVersion,
+		},
+		"language": {
+			name:        "language",
+			description: "Shows pokemon and location names in the given language (such as de, fr or ja), shows it when no code is given, or clears it with `language clear`; translated names can be typed as input once shown",
+			callback:    commandLanguage,
+		},
+		"config": {
+			name:        "config",
+			description: "Shows the settings with `config get [setting]`, changes one for this session with `config set <setting> <value>`, lists or switches config file profiles with `config profile [name]`, or shows where the config file is read from with `config path`",
+			callback:    commandConfigure,
+			rawArgs:     true,
+		},
+		"trainer": {
+			name:        "trainer",
+			description: "Shows your trainer card with play time, badges, pokedex counts and catch statistics; manage save files with `trainer new <name>`, `trainer switch <name>`, `trainer list` and `trainer delete <name>`",
+			callback:    commandTrainer,
+		},
+		"export": {
+			name:        "export",
+			description: "Writes your collection to a file, or prints it when no file is given; the format is json, csv, md (markdown) or showdown (your party as a Pokemon Showdown team), taken from the file extension or --format; an existing file is only overwritten once confirmed or with --force",
+			callback:    commandExport,
+			rawArgs:     true,
+		},
+		"import": {
+			name:        "import",
+			description: "Reads a collection from a json, csv, md or showdown file written by export, checking every species, ability and move against the API; --strategy=merge (the default) adds it to your collection and --strategy=replace swaps your collection for it",
+			callback:    commandImport,
+			rawArgs:     true,
+		},
+		"undo": {
+			name:        "undo",
+			description: "Reverts the last release, nickname, transfer or import",
+			callback:    commandUndo,
+		},
 	}
 }
 
@@ -79,40 +224,99 @@ func cleanInput(text string) []string {
 	return words
 }
 
-func startRepl() {
+func startRepl(config *commandConfig) {
 	reader := bufio.NewScanner(os.Stdin)
-	config := commandConfig{}
-	for {
-		fmt.Print("Pokedex > ")
-		reader.Scan()
+	config.input = reader
 
-		words := cleanInput(reader.Text())
-		if len(words) == 0 {
-			continue
+	// A second Ctrl-C at the prompt exits, but the exit is left to the loop
+	// below so the trainer isn't saved while a command is changing it.
+	interrupts := &interruptHandler{}
+	exits := make(chan struct{}, 1)
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt)
+	go func() {
+		for range signals {
+			cancelled, exit := interrupts.interrupt()
+			switch {
+			case exit:
+				exits <- struct{}{}
+			case !cancelled:
+				fmt.Print("\n(press Ctrl-C again or type exit to quit)\nPokedex > ")
+			default:
+				fmt.Println()
+			}
 		}
+	}()
 
-		commandName := words[0]
-		var args string
-		if len(words) > 1 {
-			args = words[1]
+	scanned := make(chan bool)
+	for {
+		fmt.Print("Pokedex > ")
+		// The prompt is read in the background so an exit can interrupt it.
+		// Nothing else reads the input meanwhile, since commands only prompt
+		// for confirmation once the line has been read.
+		go func() { scanned <- reader.Scan() }()
+		select {
+		case ok := <-scanned:
+			if !ok {
+				fmt.Println()
+				commandExit(context.Background(), config, nil)
+			}
+		case <-exits:
+			fmt.Println()
+			commandExit(context.Background(), config, nil)
 		}
+		ctx := interrupts.start()
+		runCommand(ctx, config, reader.Text())
+		interrupts.finish()
+	}
+}
 
-		command, ok := supportedCommands[commandName]
-		if ok {
-			command.callback(&config, args)
-		} else {
-			fmt.Println("Unknown command")
-		}
+// runCommand runs a line of input, then saves the trainer if the command
+// changed anything that is saved.
+func runCommand(ctx context.Context, config *commandConfig, line string) {
+	words := cleanInput(line)
+	if len(words) == 0 {
+		return
+	}
+
+	commandName := words[0]
+	args := words[1:]
+
+	command, ok := supportedCommands[commandName]
+	if !ok {
+		fmt.Println("Unknown command")
+		return
+	}
+	if command.rawArgs {
+		args = strings.Fields(line)[1:]
+	}
+	if command.nameArgs != 0 {
+		args = config.locale.slugArgs(args, command.nameArgs)
+	}
+	command.callback(ctx, config, args)
+	if ctx.Err() != nil {
+		fmt.Println("Cancelled")
+	}
+	if !config.dirty {
+		return
 	}
+	if err := config.saveTrainer(); err != nil {
+		fmt.Println(err)
+		return
+	}
+	config.dirty = false
 }
 
-func commandExit(config *commandConfig, args string) error {
+func commandExit(ctx context.Context, config *commandConfig, args []string) error {
+	if err := config.saveTrainer(); err != nil {
+		fmt.Println(err)
+	}
 	fmt.Println("Closing the Pokedex... Goodbye!")
 	os.Exit(0)
 	return nil
 }
 
-func commandHelp(config *commandConfig, args string) error {
+func commandHelp(ctx context.Context, config *commandConfig, args []string) error {
 	helpMsg := "\nWelcome to the Pokedex!\nUsage:\n\n"
 	for _, c := range supportedCommands {
 		helpMsg += fmt.Sprintf("%v: %v\n", c.name, c.description)
@@ -121,137 +325,169 @@ func commandHelp(config *commandConfig, args string) error {
 	return nil
 }
 
-func commandMap(config *commandConfig, args string) error {
-	if config.next == "" {
-		config.next = "https://pokeapi.co/api/v2/location-area/"
+func commandMap(ctx context.Context, config *commandConfig, args []string) error {
+	_, flags := parseFlags(args, "page", "limit")
+	if limit, ok := flags["limit"]; ok {
+		n, err := strconv.Atoi(limit)
+		if err != nil || n < 1 {
+			fmt.Println("limit must be a positive number")
+			return nil
+		}
+		if config.locations == nil || config.locations.Limit() != n {
+			config.locations = pokeapi.NewPaginator(pokeapi.DefaultClient, pokeapi.BaseURL+"location-area/", n)
+		}
 	}
-	list, err := pokeapi.GetFromAPI[pokeapi.LocationAreaList](config.next)
-	if err != nil {
-		return fmt.Errorf("error getting data from API: %w", err)
+
+	var names []string
+	var err error
+	if page, ok := flags["page"]; ok {
+		n, convErr := strconv.Atoi(page)
+		if convErr != nil {
+			fmt.Println("page must be a number")
+			return nil
+		}
+		names, err = locationAreaPage(ctx, config, n)
+	} else {
+		names, err = nextLocationAreas(ctx, config)
+	}
+	if pageError(err) {
+		fmt.Println(err)
+		return nil
 	}
-	config.next = list.Next
-	if list.Previous != nil {
-		config.previous = *list.Previous
+	if err != nil {
+		fmt.Println(err)
+		return err
 	}
-	page := ""
-	for _, area := range list.Results {
-		page += "\n" + area.Name
+	if err := config.locale.localizeAreas(ctx, names); err != nil {
+		fmt.Printf("error getting data from API: %v\n", err)
+		return fmt.Errorf("error getting data from API: %w", err)
 	}
-	fmt.Println(page)
-	config.pageNum++
+	printLocationAreas(config, names)
 	return nil
 }
 
-func commandMapb(config *commandConfig, args string) error {
-	if config.previous == "" {
+func commandMapb(ctx context.Context, config *commandConfig, args []string) error {
+	if onFirstLocationPage(config) {
 		fmt.Println("you're on the first page")
 		return nil
 	}
-	if config.pageNum == 1 {
-		fmt.Println("you're on the first page")
+	names, err := previousLocationAreas(ctx, config)
+	if pageError(err) {
+		fmt.Println(err)
 		return nil
 	}
-	list, err := pokeapi.GetFromAPI[pokeapi.LocationAreaList](config.previous)
 	if err != nil {
-		return fmt.Errorf("error getting data from API: %w", err)
+		fmt.Println(err)
+		return err
 	}
-	config.next = list.Next
-	if list.Previous != nil {
-		config.previous = *list.Previous
-	}
-	page := ""
-	for _, area := range list.Results {
-		page += "\n" + area.Name
+	if err := config.locale.localizeAreas(ctx, names); err != nil {
+		fmt.Printf("error getting data from API: %v\n", err)
+		return fmt.Errorf("error getting data from API: %w", err)
 	}
-	fmt.Println(page)
-	config.pageNum--
+	printLocationAreas(config, names)
 	return nil
 }
 
-func commandExplore(config *commandConfig, args string) error {
-	if config.exploreURL == "" {
-		config.exploreURL = "https://pokeapi.co/api/v2/location-area/"
+func printLocationAreas(config *commandConfig, names []string) {
+	fmt.Println()
+	for _, name := range names {
+		fmt.Println(config.locale.display(name))
 	}
-	if config.exploreURL+args == config.exploreURL {
-		fmt.Println("Empty argument! Please try again")
-		return nil
+	fmt.Printf("\nPage %d of %d\n", config.locations.Current(), config.locations.Pages())
+}
+
+func locationPaginator(config *commandConfig) *pokeapi.Paginator {
+	if config.locations == nil {
+		config.locations = pokeapi.NewPaginator(pokeapi.DefaultClient, pokeapi.BaseURL+"location-area/", config.options.pageSize)
+	}
+	return config.locations
+}
+
+// nextLocationAreas fetches the next page of location area names.
+func nextLocationAreas(ctx context.Context, config *commandConfig) ([]string, error) {
+	return resultNames(locationPaginator(config).Next(ctx))
+}
+
+// previousLocationAreas fetches the previous page of location area names.
+func previousLocationAreas(ctx context.Context, config *commandConfig) ([]string, error) {
+	return resultNames(locationPaginator(config).Previous(ctx))
+}
+
+// locationAreaPage fetches page n of location area names.
+func locationAreaPage(ctx context.Context, config *commandConfig, n int) ([]string, error) {
+	return resultNames(locationPaginator(config).Page(ctx, n))
+}
+
+func onFirstLocationPage(config *commandConfig) bool {
+	return locationPaginator(config).Current() <= 1
+}
+
+// pageError reports whether err is from asking for a page that doesn't
+// exist rather than from the API.
+func pageError(err error) bool {
+	return errors.Is(err, pokeapi.ErrFirstPage) || errors.Is(err, pokeapi.ErrLastPage) || errors.Is(err, pokeapi.ErrNoPage)
+}
+
+func resultNames(results []pokeapi.Result, err error) ([]string, error) {
+	if pageError(err) {
+		return nil, err
 	}
-	list, err := pokeapi.GetFromAPI[pokeapi.LocationArea](config.exploreURL + args)
 	if err != nil {
-		fmt.Printf("error getting data from API: %v\n", err)
-		return fmt.Errorf("error getting data from API: %w", err)
+		return nil, fmt.Errorf("error getting data from API: %w", err)
 	}
-	fmt.Println("Exploring " + args + "...\nFound Pokemon:")
-	for _, pokemon := range list.PokemonEncounters {
-		fmt.Printf(" - %s\n", pokemon.Pokemon.Name)
+	names := make([]string, 0, len(results))
+	for _, result := range results {
+		names = append(names, result.Name)
 	}
-	return nil
+	return names, nil
 }
 
-func commandCatch(config *commandConfig, args string) error {
-	pokemonURL := "https://pokeapi.co/api/v2/pokemon/"
+func commandCatch(ctx context.Context, config *commandConfig, args []string) error {
+	pokemonURL := pokeapi.BaseURL + "pokemon/"
 
-	if pokemonURL+args == pokemonURL {
+	if len(args) == 0 {
 		fmt.Println("No pokemon selected! Please try again")
 		return nil
 	}
+	if !config.storage.hasRoom() {
+		fmt.Println("Your party and every PC box are full, release or transfer a pokemon before catching more")
+		return nil
+	}
 
-	pkmn, err := pokeapi.GetFromAPI[pokeapi.Pokemon](pokemonURL + args)
+	pkmn, err := pokeapi.GetFromAPI[pokeapi.Pokemon](ctx, pokemonURL+args[0])
 	if err != nil {
 		fmt.Printf("error getting data from API: %v\n", err)
 		return fmt.Errorf("error getting data from API: %w", err)
 	}
 	fmt.Printf("Throwing a Pokeball at %v...\n", pkmn.Name)
-
-	if config.pokedex == nil {
-		config.pokedex = make(map[string]pokeapi.Pokemon)
-	}
+	config.markSeen(speciesName(pkmn))
+	config.trainer.throws++
+	config.dirty = true
 
 	captureChance := 20.0 / float64(pkmn.BaseExperience)
 	randomValue := rand.Float64()
 
 	if randomValue < captureChance {
-		config.pokedex[pkmn.Name] = pkmn
+		loc, err := config.storage.add(ownedPokemon{
+			ID:             config.nextID + 1,
+			Pokemon:        pkmn,
+			CaughtAt:       time.Now(),
+			CaughtLocation: config.area,
+		})
+		if err != nil {
+			fmt.Printf("%v broke free because %v\n", pkmn.Name, err)
+			return nil
+		}
 		fmt.Printf("%v was caught!\n", pkmn.Name)
+		config.changed()
+		config.markCaught(speciesName(pkmn))
+		config.trainer.catches++
+		config.nextID++
+		if loc.box != 0 {
+			fmt.Printf("Your party is full, so %v was sent to %v\n", pkmn.Name, loc)
+		}
 		return nil
 	}
 	fmt.Printf("%v escaped!\n", pkmn.Name)
 	return nil
 }
-
-func commandInspect(config *commandConfig, args string) error {
-	pkmn, ok := config.pokedex[args]
-	if !ok {
-		fmt.Println("you have not caught that pokemon")
-		return nil
-	}
-	var output strings.Builder
-
-	output.WriteString(fmt.Sprintf("Name: %v\n", pkmn.Name))
-	output.WriteString(fmt.Sprintf("Height: %d\n", pkmn.Height))
-	output.WriteString(fmt.Sprintf("Weight: %d\n", pkmn.Weight))
-	output.WriteString(fmt.Sprintf("Stats: \n"))
-
-	for _, stat := range pkmn.Stats {
-		output.WriteString(fmt.Sprintf("  -%v: %v\n", stat.Stat.Name, stat.BaseStat))
-	}
-	output.WriteString("Types:\n")
-	for _, typ := range pkmn.Types {
-		output.WriteString(fmt.Sprintf("  - %v\n", typ.Type.Name))
-	}
-
-	fmt.Println(output.String())
-
-	return nil
-}
-
-func commandPokedex(config *commandConfig, args string) error {
-	fmt.Println("Your Pokedex:")
-	if len(config.pokedex) == 0 {
-		fmt.Println("  - <empty>")
-	}
-	for k, _ := range config.pokedex {
-		fmt.Printf("  - %v\n", k)
-	}
-	return nil
-}
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"testing"
 )
 
@@ -44,3 +45,48 @@ func TestCleanInput(t *testing.T) {
 		}
 	}
 }
+
+func TestCatchWithFullStorage(t *testing.T) {
+	var config commandConfig
+	for id := 1; ; id++ {
+		if _, err := config.storage.add(newOwned(id, "pidgey")); err != nil {
+			break
+		}
+	}
+	owned := len(config.storage.all())
+
+	if err := commandCatch(context.Background(), &config, []string{"rattata"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if config.trainer.throws != 0 || config.trainer.catches != 0 || config.caught["rattata"] {
+		t.Errorf("expected no throw or catch to be counted, got %+v", config.trainer)
+	}
+	if n := len(config.storage.all()); n != owned {
+		t.Errorf("expected %d pokemon, got %d", owned, n)
+	}
+}
+
+func TestMapPastTheLastPage(t *testing.T) {
+	serveAPI(t, map[string]string{
+		"location-area/": `{"count": 1, "results": [{"name": "canalave-city-area"}]}`,
+	})
+	var config commandConfig
+
+	output := captureOutput(func() {
+		if err := commandMap(context.Background(), &config, []string{"--page", "1000"}); err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+	})
+	if output != "that page doesn't exist, there are 1 pages\n" {
+		t.Errorf("expected the page to be refused, got %q", output)
+	}
+	commandMap(context.Background(), &config, nil)
+	output = captureOutput(func() {
+		if err := commandMap(context.Background(), &config, nil); err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+	})
+	if output != "you're on the last page\n" {
+		t.Errorf("expected the last page message alone, got %q", output)
+	}
+}
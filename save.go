@@ -0,0 +1,346 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/zorahscope/pokedexcli/internal/pokeapi"
+)
+
+const saveExt = ".json"
+
+// saveVersion is the version of the save file format written. Version 1
+// files, from before the version was recorded, kept each pokemon's whole API
+// response under untagged keys. Version 2 keeps only its name, and the rest
+// is fetched again when the trainer is loaded.
+const saveVersion = 2
+
+// currentTrainerFile names the file in the data directory that remembers
+// which trainer was played last.
+const currentTrainerFile = "current"
+
+var trainerNamePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]*$`)
+
+// saveFile is the on-disk form of a trainer and everything they own.
+type saveFile struct {
+	Version  int                      `json:"version"`
+	Name     string                   `json:"name"`
+	Started  time.Time                `json:"started"`
+	PlayTime time.Duration            `json:"play_time"`
+	Throws   int                      `json:"throws"`
+	Catches  int                      `json:"catches"`
+	NextID   int                      `json:"next_id"`
+	Seen     []string                 `json:"seen"`
+	Caught   []string                 `json:"caught"`
+	Party    []ownedPokemon           `json:"party"`
+	Boxes    [boxCount][]ownedPokemon `json:"boxes"`
+	Team     []ownedPokemon           `json:"team,omitempty"`
+}
+
+// dataDir returns where save files are kept: $POKEDEX_DATA_DIR when it's set,
+// otherwise pokedexcli in $XDG_DATA_HOME or ~/.local/share.
+func dataDir() (string, error) {
+	if dir := os.Getenv("POKEDEX_DATA_DIR"); dir != "" {
+		return dir, nil
+	}
+	if dir := os.Getenv("XDG_DATA_HOME"); dir != "" {
+		return filepath.Join(dir, "pokedexcli"), nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".local", "share", "pokedexcli"), nil
+}
+
+func trainerPath(name string) (string, error) {
+	dir, err := dataDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "trainers", name+saveExt), nil
+}
+
+func validTrainerName(name string) bool {
+	return trainerNamePattern.MatchString(name)
+}
+
+// toSaveFile captures the trainer's progress, including the time played so
+// far this session.
+func (config *commandConfig) toSaveFile() saveFile {
+	return saveFile{
+		Version:  saveVersion,
+		Name:     config.trainer.name,
+		Started:  config.trainer.started,
+		PlayTime: config.trainer.playTime(),
+		Throws:   config.trainer.throws,
+		Catches:  config.trainer.catches,
+		NextID:   config.nextID,
+		Seen:     sortedKeys(config.seen),
+		Caught:   sortedKeys(config.caught),
+		Party:    config.storage.party,
+		Boxes:    config.storage.boxes,
+		Team:     config.team,
+	}
+}
+
+// loadSaveFile replaces the session's trainer and collection with save, and
+// starts timing a new play session.
+func (config *commandConfig) loadSaveFile(save saveFile) {
+	config.trainer = trainerState{
+		name:         save.Name,
+		started:      save.Started,
+		played:       save.PlayTime,
+		sessionStart: time.Now(),
+		throws:       save.Throws,
+		catches:      save.Catches,
+	}
+	config.nextID = save.NextID
+	config.storage = storage{party: save.Party, boxes: save.Boxes}
+	config.team = save.Team
+	config.seen = make(map[string]bool)
+	for _, name := range save.Seen {
+		config.seen[name] = true
+	}
+	config.caught = make(map[string]bool)
+	for _, name := range save.Caught {
+		config.caught[name] = true
+	}
+	config.lastUndo = nil
+	config.dirty = false
+}
+
+func sortedKeys[V any](set map[string]V) []string {
+	keys := make([]string, 0, len(set))
+	for key := range set {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// writeSave writes the save file for save.Name, replacing it atomically so a
+// crash can't leave a half written save behind, and remembers it as the
+// trainer to load next time.
+func writeSave(save saveFile) error {
+	path, err := trainerPath(save.Name)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(save, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		return err
+	}
+	dir, _ := dataDir()
+	return os.WriteFile(filepath.Join(dir, currentTrainerFile), []byte(save.Name+"\n"), 0o644)
+}
+
+func readSave(name string) (saveFile, error) {
+	var save saveFile
+	path, err := trainerPath(name)
+	if err != nil {
+		return save, err
+	}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return save, fmt.Errorf("there's no trainer named %v", name)
+	}
+	if err != nil {
+		return save, err
+	}
+	if err := decodeSave(data, &save); err != nil {
+		return save, fmt.Errorf("save file %v is corrupt: %w", path, err)
+	}
+	if save.Version > saveVersion {
+		return save, fmt.Errorf("save file %v was written by a newer version of the Pokedex", path)
+	}
+	return save, nil
+}
+
+// decodeSave reads a save file of any version into save.
+func decodeSave(data []byte, save *saveFile) error {
+	var header struct {
+		Version int `json:"version"`
+	}
+	if err := json.Unmarshal(data, &header); err != nil {
+		return err
+	}
+	if header.Version >= 2 {
+		return json.Unmarshal(data, save)
+	}
+
+	var v1 struct {
+		saveFile
+		Party []ownedPokemonV1           `json:"party"`
+		Boxes [boxCount][]ownedPokemonV1 `json:"boxes"`
+		Team  []ownedPokemonV1           `json:"team"`
+	}
+	if err := json.Unmarshal(data, &v1); err != nil {
+		return err
+	}
+	*save = v1.saveFile
+	save.Version = saveVersion
+	save.Party = fromV1(v1.Party)
+	for i, box := range v1.Boxes {
+		save.Boxes[i] = fromV1(box)
+	}
+	save.Team = fromV1(v1.Team)
+	return nil
+}
+
+// ownedPokemonV1 is an owned pokemon as version 1 save files hold it.
+type ownedPokemonV1 struct {
+	ID             int
+	Pokemon        pokeapi.Pokemon
+	Nickname       string
+	CaughtAt       time.Time
+	CaughtLocation string
+	Item           string
+	Ability        string
+	Nature         string
+	EVs            statSpread
+	IVs            *statSpread
+	Moves          []string
+}
+
+func fromV1(pokemon []ownedPokemonV1) []ownedPokemon {
+	if pokemon == nil {
+		return nil
+	}
+	owned := make([]ownedPokemon, len(pokemon))
+	for i, p := range pokemon {
+		owned[i] = ownedPokemon(p)
+	}
+	return owned
+}
+
+// MarshalJSON saves an owned pokemon with the name of its pokemon in place of
+// the API response, which fetchSavedPokemon gets again on load.
+func (p ownedPokemon) MarshalJSON() ([]byte, error) {
+	type fields ownedPokemon
+	return json.Marshal(struct {
+		fields
+		Pokemon string `json:"pokemon"`
+	}{fields(p), p.Pokemon.Name})
+}
+
+func (p *ownedPokemon) UnmarshalJSON(data []byte) error {
+	type fields ownedPokemon
+	var saved struct {
+		fields
+		Pokemon string `json:"pokemon"`
+	}
+	if err := json.Unmarshal(data, &saved); err != nil {
+		return err
+	}
+	*p = ownedPokemon(saved.fields)
+	p.Pokemon = pokeapi.Pokemon{Name: saved.Pokemon}
+	return nil
+}
+
+// fetchSavedPokemon fetches the API data of the pokemon in save that were
+// read by name alone. It's best effort: pokemon that can't be fetched keep
+// just their name, and the first error is returned to warn about.
+func fetchSavedPokemon(ctx context.Context, save *saveFile) error {
+	pending := map[string][]*ownedPokemon{}
+	collect := func(pokemon []ownedPokemon) {
+		for i := range pokemon {
+			if p := &pokemon[i]; p.Pokemon.ID == 0 {
+				pending[p.Pokemon.Name] = append(pending[p.Pokemon.Name], p)
+			}
+		}
+	}
+	collect(save.Party)
+	for _, box := range save.Boxes {
+		collect(box)
+	}
+	collect(save.Team)
+
+	names := sortedKeys(pending)
+	urls := make([]string, len(names))
+	for i, name := range names {
+		urls[i] = pokeapi.BaseURL + "pokemon/" + name
+	}
+	pokemon, errs := pokeapi.GetEach[pokeapi.Pokemon](ctx, pokeapi.DefaultClient, urls)
+	var failed error
+	for i, name := range names {
+		if errs[i] != nil {
+			if failed == nil {
+				failed = fmt.Errorf("error getting data from API: %w", errs[i])
+			}
+			continue
+		}
+		for _, p := range pending[name] {
+			p.Pokemon = pokemon[i]
+		}
+	}
+	return failed
+}
+
+// listSaves returns the names of every saved trainer, sorted.
+func listSaves() ([]string, error) {
+	dir, err := dataDir()
+	if err != nil {
+		return nil, err
+	}
+	entries, err := os.ReadDir(filepath.Join(dir, "trainers"))
+	if errors.Is(err, fs.ErrNotExist) {
+		return []string{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	names := []string{}
+	for _, entry := range entries {
+		if name, ok := strings.CutSuffix(entry.Name(), saveExt); ok && !entry.IsDir() {
+			names = append(names, name)
+		}
+	}
+	return names, nil
+}
+
+func deleteSave(name string) error {
+	path, err := trainerPath(name)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(path); errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("there's no trainer named %v", name)
+	} else if err != nil {
+		return err
+	}
+	return nil
+}
+
+// lastTrainer returns the trainer that was played last, or "" when there
+// isn't one.
+func lastTrainer() string {
+	dir, err := dataDir()
+	if err != nil {
+		return ""
+	}
+	data, err := os.ReadFile(filepath.Join(dir, currentTrainerFile))
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(data))
+}
@@ -0,0 +1,184 @@
+package main
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSaveRoundTrip(t *testing.T) {
+	t.Setenv("POKEDEX_DATA_DIR", t.TempDir())
+
+	var config commandConfig
+	config.trainer = trainerState{name: "red", started: time.Now(), played: time.Hour, throws: 3, catches: 2}
+	config.markSeen("pidgey")
+	config.markCaught("pidgey")
+	config.nextID = 2
+	config.storage.add(newOwned(1, "pidgey"))
+	config.storage.boxes[2] = append(config.storage.boxes[2], newOwned(2, "rattata"))
+	if err := config.saveTrainer(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if name := lastTrainer(); name != "red" {
+		t.Errorf("expected red to be remembered as the last trainer, got %q", name)
+	}
+	save, err := readSave("red")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var loaded commandConfig
+	loaded.loadSaveFile(save)
+	if loaded.trainer.name != "red" || loaded.trainer.throws != 3 || loaded.nextID != 2 {
+		t.Errorf("unexpected trainer %+v", loaded.trainer)
+	}
+	if loaded.trainer.played < time.Hour {
+		t.Errorf("expected play time to be kept, got %v", loaded.trainer.played)
+	}
+	if !loaded.caught["pidgey"] || len(loaded.storage.party) != 1 || len(loaded.storage.boxes[2]) != 1 {
+		t.Errorf("unexpected collection %+v", loaded.storage)
+	}
+	if loaded.storage.boxes[2][0].Pokemon.Name != "rattata" {
+		t.Errorf("expected rattata in box 3, got %+v", loaded.storage.boxes[2])
+	}
+
+	if names, err := listSaves(); err != nil || len(names) != 1 || names[0] != "red" {
+		t.Errorf("expected only red to be listed, got %v, %v", names, err)
+	}
+	if err := deleteSave("red"); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if _, err := readSave("red"); err == nil {
+		t.Error("expected an error reading a deleted trainer")
+	}
+}
+
+func TestEarnedBadges(t *testing.T) {
+	if got := earnedBadges(9); len(got) != 0 {
+		t.Errorf("expected no badges for 9 species, got %v", got)
+	}
+	if got := earnedBadges(25); len(got) != 2 || got[1] != "Cascade" {
+		t.Errorf("expected two badges for 25 species, got %v", got)
+	}
+	if got := earnedBadges(500); len(got) != len(badges) {
+		t.Errorf("expected every badge, got %v", got)
+	}
+}
+
+func TestRunCommandSavesOnlyChanges(t *testing.T) {
+	t.Setenv("POKEDEX_DATA_DIR", t.TempDir())
+	config := answering("y")
+	config.trainer = trainerState{name: "red", started: time.Now()}
+	config.storage.add(newOwned(1, "pidgey"))
+	config.storage.add(newOwned(2, "rattata"))
+	saved := func() bool {
+		_, err := readSave("red")
+		return err == nil
+	}
+
+	for _, line := range []string{"help", "party", "inspect mew", "release mew", "bogus"} {
+		captureOutput(func() { runCommand(context.Background(), config, line) })
+		if saved() {
+			t.Fatalf("expected %q not to save the trainer", line)
+		}
+	}
+
+	captureOutput(func() { runCommand(context.Background(), config, "release pidgey") })
+	save, err := readSave("red")
+	if err != nil {
+		t.Fatalf("expected the release to save the trainer: %v", err)
+	}
+	if len(save.Party) != 1 || save.Party[0].Pokemon.Name != "rattata" {
+		t.Errorf("expected the saved party to be rattata alone, got %+v", save.Party)
+	}
+
+	deleteSave("red")
+	captureOutput(func() { runCommand(context.Background(), config, "party") })
+	if saved() {
+		t.Fatal("expected the trainer to be saved only once per change")
+	}
+	captureOutput(func() { runCommand(context.Background(), config, "team add rattata") })
+	if save, err := readSave("red"); err != nil || len(save.Team) != 1 {
+		t.Errorf("expected the team change to save the trainer, got %+v, %v", save.Team, err)
+	}
+}
+
+func TestSaveKeepsPokemonByName(t *testing.T) {
+	t.Setenv("POKEDEX_DATA_DIR", t.TempDir())
+	serveAPI(t, map[string]string{
+		"pokemon/pidgey":           `{"id": 16, "name": "pidgey", "base_experience": 50}`,
+		"pokemon/giratina-altered": `{"id": 487, "name": "giratina-altered", "species": {"name": "giratina"}}`,
+	})
+	var config commandConfig
+	config.trainer = trainerState{name: "red", started: time.Now()}
+	pidgey := newOwned(1, "pidgey")
+	pidgey.Pokemon.ID, pidgey.Pokemon.BaseExperience = 16, 50
+	pidgey.Nickname = "Sky King"
+	config.storage.add(pidgey)
+	config.storage.add(newOwned(2, "giratina-altered"))
+	config.team = []ownedPokemon{pidgey}
+	if err := config.saveTrainer(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	path, _ := trainerPath("red")
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{`"version": 2`, `"pokemon": "pidgey"`, `"nickname": "Sky King"`, `"caught_at"`} {
+		if !strings.Contains(string(data), want) {
+			t.Errorf("expected %v in the save file, got:\n%s", want, data)
+		}
+	}
+	if strings.Contains(string(data), "base_experience") || strings.Contains(string(data), `"ID"`) {
+		t.Errorf("expected only the pokemon's name to be saved, got:\n%s", data)
+	}
+
+	var loaded commandConfig
+	if err := loadLastTrainer(context.Background(), &loaded); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	owned := loaded.storage.all()
+	if len(owned) != 2 || owned[0].Pokemon.BaseExperience != 50 || owned[0].Nickname != "Sky King" ||
+		speciesName(owned[1].Pokemon) != "giratina" || loaded.team[0].Pokemon.ID != 16 {
+		t.Errorf("expected the pokemon to be fetched again, got %+v and team %+v", owned, loaded.team)
+	}
+}
+
+func TestReadVersion1Save(t *testing.T) {
+	t.Setenv("POKEDEX_DATA_DIR", t.TempDir())
+	path, _ := trainerPath("red")
+	os.MkdirAll(filepath.Dir(path), 0o755)
+	v1 := `{"name": "red", "next_id": 1, "caught": ["pidgey"],
+		"party": [{"ID": 1, "Pokemon": {"id": 16, "name": "pidgey", "base_experience": 50},
+			"Nickname": "Sky King", "CaughtAt": "2024-05-01T10:00:00Z", "CaughtLocation": "route-1-area", "EVs": [0, 0, 0, 0, 0, 0]}],
+		"boxes": [[], [{"ID": 2, "Pokemon": {"id": 19, "name": "rattata"}}]]}`
+	if err := os.WriteFile(path, []byte(v1), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	save, err := readSave("red")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if save.Version != saveVersion || len(save.Party) != 1 || len(save.Boxes[1]) != 1 {
+		t.Fatalf("unexpected save %+v", save)
+	}
+	pidgey := save.Party[0]
+	if pidgey.ID != 1 || pidgey.Pokemon.BaseExperience != 50 || pidgey.Nickname != "Sky King" ||
+		pidgey.CaughtLocation != "route-1-area" || pidgey.CaughtAt.Year() != 2024 {
+		t.Errorf("unexpected pokemon %+v", pidgey)
+	}
+	if save.Boxes[1][0].Pokemon.Name != "rattata" {
+		t.Errorf("expected rattata in box 2, got %+v", save.Boxes[1])
+	}
+
+	os.WriteFile(path, []byte(`{"version": 99, "name": "red"}`), 0o644)
+	if _, err := readSave("red"); err == nil || !strings.Contains(err.Error(), "newer version") {
+		t.Errorf("expected a newer save to be refused, got %v", err)
+	}
+}
@@ -0,0 +1,392 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/zorahscope/pokedexcli/internal/configfile"
+	"github.com/zorahscope/pokedexcli/internal/pokeapi"
+	"github.com/zorahscope/pokedexcli/internal/termimg"
+)
+
+const (
+	defaultCacheTTL = 15 * time.Minute
+	defaultTimeout  = 30 * time.Second
+)
+
+// options holds the settings that only change how commands behave, rather
+// than session state such as the game version or language.
+type options struct {
+	profile    string
+	cacheTTL   time.Duration
+	timeout    time.Duration
+	pageSize   int
+	imperial   bool
+	spriteMode string
+	// outputFormat is the format export prints in, or writes files without
+	// an extension in; empty means md.
+	outputFormat string
+}
+
+// setting is a value that can be read from the config file, overridden with
+// an environment variable and changed with `config set`.
+type setting struct {
+	name        string
+	description string
+	defaultVal  string
+	get         func(config *commandConfig) string
+	set         func(ctx context.Context, config *commandConfig, value string) error
+	// rawValue keeps the case of values given with `config set`, which are
+	// otherwise lowercased like the rest of the input.
+	rawValue bool
+}
+
+var settings = []setting{
+	{
+		name:        "base_url",
+		description: "root of the PokeAPI v2 endpoints",
+		defaultVal:  pokeapi.DefaultBaseURL,
+		rawValue:    true,
+		get:         func(config *commandConfig) string { return pokeapi.BaseURL },
+		set: func(ctx context.Context, config *commandConfig, value string) error {
+			u, err := url.Parse(value)
+			if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+				return fmt.Errorf("%q isn't an http or https URL", value)
+			}
+			if !strings.HasSuffix(value, "/") {
+				value += "/"
+			}
+			pokeapi.BaseURL = value
+			config.locations = nil
+			config.exploreURL = ""
+			return nil
+		},
+	},
+	{
+		name:        "cache_ttl",
+		description: "how long API responses are cached, e.g. 15m or 1h",
+		defaultVal:  defaultCacheTTL.String(),
+		get:         func(config *commandConfig) string { return durationOr(config.options.cacheTTL, defaultCacheTTL) },
+		set: func(ctx context.Context, config *commandConfig, value string) error {
+			d, err := parsePositiveDuration(value)
+			if err != nil {
+				return err
+			}
+			pokeapi.DefaultClient.SetCacheTTL(d)
+			config.options.cacheTTL = d
+			return nil
+		},
+	},
+	{
+		name:        "timeout",
+		description: "how long a single request may take, e.g. 30s",
+		defaultVal:  defaultTimeout.String(),
+		get:         func(config *commandConfig) string { return durationOr(config.options.timeout, defaultTimeout) },
+		set: func(ctx context.Context, config *commandConfig, value string) error {
+			d, err := parsePositiveDuration(value)
+			if err != nil {
+				return err
+			}
+			pokeapi.DefaultClient.SetTimeout(d)
+			config.options.timeout = d
+			return nil
+		},
+	},
+	{
+		name:        "page_size",
+		description: "number of location areas map shows per page",
+		defaultVal:  strconv.Itoa(pokeapi.DefaultPageSize),
+		get: func(config *commandConfig) string {
+			if config.options.pageSize == 0 {
+				return strconv.Itoa(pokeapi.DefaultPageSize)
+			}
+			return strconv.Itoa(config.options.pageSize)
+		},
+		set: func(ctx context.Context, config *commandConfig, value string) error {
+			n, err := strconv.Atoi(value)
+			if err != nil || n < 1 {
+				return fmt.Errorf("page_size must be a positive number")
+			}
+			config.options.pageSize = n
+			config.locations = nil
+			return nil
+		},
+	},
+	{
+		name:        "units",
+		description: "metric or imperial heights and weights in inspect",
+		defaultVal:  "metric",
+		get: func(config *commandConfig) string {
+			if config.options.imperial {
+				return "imperial"
+			}
+			return "metric"
+		},
+		set: func(ctx context.Context, config *commandConfig, value string) error {
+			if value != "metric" && value != "imperial" {
+				return fmt.Errorf("units must be metric or imperial")
+			}
+			config.options.imperial = value == "imperial"
+			return nil
+		},
+	},
+	{
+		name:        "sprite_mode",
+		description: "how sprites are drawn: auto, kitty, iterm2, sixel, truecolor, 256 or ascii",
+		defaultVal:  "auto",
+		get: func(config *commandConfig) string {
+			if config.options.spriteMode == "" {
+				return "auto"
+			}
+			return config.options.spriteMode
+		},
+		set: func(ctx context.Context, config *commandConfig, value string) error {
+			if value == "auto" {
+				config.options.spriteMode = ""
+				return nil
+			}
+			if _, ok := termimg.ParseProtocol(value); !ok {
+				if _, err := termimg.ParseMode(value); err != nil {
+					return err
+				}
+			}
+			config.options.spriteMode = value
+			return nil
+		},
+	},
+	{
+		name:        "output_format",
+		description: "format export uses when the file doesn't name one: json, csv, md or showdown",
+		defaultVal:  "md",
+		get:         func(config *commandConfig) string { return config.outputFormat() },
+		set: func(ctx context.Context, config *commandConfig, value string) error {
+			format, err := exportFormat("", value)
+			if err != nil {
+				return fmt.Errorf("output_format must be json, csv, md or showdown")
+			}
+			config.options.outputFormat = format
+			return nil
+		},
+	},
+	{
+		name:        "language",
+		description: "language code names are shown in, empty for the API's names",
+		get:         func(config *commandConfig) string { return config.locale.language },
+		set: func(ctx context.Context, config *commandConfig, value string) error {
+			if value == "" || value == "clear" {
+				config.locale.reset("")
+				return nil
+			}
+			language, codes, err := findLanguage(ctx, value)
+			if err != nil {
+				return fmt.Errorf("error getting data from API: %w", err)
+			}
+			if language == "" {
+				return fmt.Errorf("%v isn't a known language, expected one of %v", value, strings.Join(codes, ", "))
+			}
+			config.locale.reset(language)
+			return nil
+		},
+	},
+	{
+		name:        "version",
+		description: "game version commands are scoped to, empty for every version",
+		get:         func(config *commandConfig) string { return config.versionName() },
+		set: func(ctx context.Context, config *commandConfig, value string) error {
+			if value == "" || value == "clear" {
+				config.version = nil
+				return nil
+			}
+			version, err := fetchGameVersion(ctx, value)
+			if err != nil {
+				return err
+			}
+			config.version = version
+			return nil
+		},
+	},
+}
+
+// outputFormat returns the format export uses when the file doesn't name
+// one.
+func (config *commandConfig) outputFormat() string {
+	if config.options.outputFormat == "" {
+		return "md"
+	}
+	return config.options.outputFormat
+}
+
+func findSetting(name string) (setting, bool) {
+	for _, s := range settings {
+		if s.name == name {
+			return s, true
+		}
+	}
+	return setting{}, false
+}
+
+func settingNames() []string {
+	names := make([]string, 0, len(settings))
+	for _, s := range settings {
+		names = append(names, s.name)
+	}
+	return names
+}
+
+func parsePositiveDuration(value string) (time.Duration, error) {
+	d, err := time.ParseDuration(value)
+	if err != nil || d <= 0 {
+		return 0, fmt.Errorf("%q isn't a duration such as 30s, 15m or 1h", value)
+	}
+	return d, nil
+}
+
+func durationOr(d, fallback time.Duration) string {
+	if d == 0 {
+		d = fallback
+	}
+	return d.String()
+}
+
+// loadSettings applies the settings from the config file for profile, with
+// environment variables taking precedence. Settings that aren't given are
+// reset to their defaults, so switching profiles doesn't keep values from
+// the previous one. Every setting is tried, and the problems are returned
+// together.
+func loadSettings(ctx context.Context, config *commandConfig, profile string) []error {
+	path, err := configfile.Path()
+	if err != nil {
+		return []error{err}
+	}
+	file, err := configfile.Load(path)
+	if err != nil {
+		return []error{err}
+	}
+	values, err := file.Resolve(profile, settingNames())
+	if err != nil {
+		return []error{err}
+	}
+
+	errs := []error{}
+	for name := range values {
+		if _, ok := findSetting(name); !ok {
+			errs = append(errs, fmt.Errorf("%v: unknown setting %q", path, name))
+		}
+	}
+	for _, s := range settings {
+		value, ok := values[s.name]
+		if !ok {
+			value = s.defaultVal
+			if s.get(config) == value {
+				continue
+			}
+		}
+		if err := s.set(ctx, config, value); err != nil {
+			errs = append(errs, fmt.Errorf("%v: %w", s.name, err))
+		}
+	}
+	config.options.profile = profile
+	return errs
+}
+
+func commandConfigure(ctx context.Context, config *commandConfig, args []string) error {
+	if len(args) == 0 {
+		args = []string{"get"}
+	}
+	// Arguments are passed as typed so URLs keep their case, but everything
+	// other than setting values and profile names is matched lowercased.
+	args[0] = strings.ToLower(args[0])
+	if len(args) > 1 && args[0] != "profile" {
+		args[1] = strings.ToLower(args[1])
+	}
+	switch args[0] {
+	case "get":
+		if len(args) > 1 {
+			s, ok := findSetting(args[1])
+			if !ok {
+				fmt.Printf("unknown setting %q, expected one of %v\n", args[1], strings.Join(settingNames(), ", "))
+				return nil
+			}
+			fmt.Println(s.get(config))
+			return nil
+		}
+		profile := config.options.profile
+		if profile == "" {
+			profile = "<none>"
+		}
+		fmt.Printf("Profile: %v\n", profile)
+		for _, s := range settings {
+			fmt.Printf("  %-12v %-32v %v\n", s.name, strconv.Quote(s.get(config)), s.description)
+		}
+	case "set":
+		if len(args) < 2 {
+			fmt.Println("usage: config set <setting> [value]")
+			return nil
+		}
+		s, ok := findSetting(args[1])
+		if !ok {
+			fmt.Printf("unknown setting %q, expected one of %v\n", args[1], strings.Join(settingNames(), ", "))
+			return nil
+		}
+		value := strings.Join(args[2:], " ")
+		if !s.rawValue {
+			value = strings.ToLower(value)
+		}
+		if err := s.set(ctx, config, value); err != nil {
+			fmt.Println(err)
+			return nil
+		}
+		fmt.Printf("%v = %q\n", s.name, s.get(config))
+	case "profile":
+		if len(args) < 2 {
+			return listProfiles(config)
+		}
+		errs := loadSettings(ctx, config, args[1])
+		for _, err := range errs {
+			fmt.Println(err)
+		}
+		if len(errs) == 0 {
+			fmt.Printf("Switched to profile %v\n", args[1])
+		}
+	case "path":
+		path, err := configfile.Path()
+		if err != nil {
+			fmt.Println(err)
+			return nil
+		}
+		fmt.Println(path)
+	default:
+		fmt.Println("usage: config [get [setting] | set <setting> [value] | profile [name] | path]")
+	}
+	return nil
+}
+
+func listProfiles(config *commandConfig) error {
+	path, err := configfile.Path()
+	if err != nil {
+		fmt.Println(err)
+		return nil
+	}
+	file, err := configfile.Load(path)
+	if err != nil {
+		fmt.Println(err)
+		return nil
+	}
+	fmt.Println("Profiles:")
+	names := file.ProfileNames()
+	if len(names) == 0 {
+		fmt.Println("  - <none>")
+	}
+	for _, name := range names {
+		marker := ""
+		if name == config.options.profile {
+			marker = " (active)"
+		}
+		fmt.Printf("  - %v%v\n", name, marker)
+	}
+	return nil
+}
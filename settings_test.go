@@ -0,0 +1,111 @@
+package main
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/zorahscope/pokedexcli/internal/pokeapi"
+)
+
+// restoreSettings puts back the package level settings a test changes.
+func restoreSettings(t *testing.T) {
+	baseURL := pokeapi.BaseURL
+	t.Cleanup(func() {
+		pokeapi.BaseURL = baseURL
+		pokeapi.DefaultClient.SetCacheTTL(defaultCacheTTL)
+		pokeapi.DefaultClient.SetTimeout(defaultTimeout)
+	})
+}
+
+func TestConfigSet(t *testing.T) {
+	restoreSettings(t)
+	var config commandConfig
+
+	cases := []struct {
+		args     []string
+		setting  string
+		expected string
+	}{
+		{[]string{"set", "base_url", "http://LocalHost:8000/API/v2"}, "base_url", "http://LocalHost:8000/API/v2/"},
+		{[]string{"SET", "Units", "Imperial"}, "units", "imperial"},
+		{[]string{"set", "cache_ttl", "1h"}, "cache_ttl", "1h0m0s"},
+		{[]string{"set", "timeout", "5s"}, "timeout", "5s"},
+		{[]string{"set", "page_size", "7"}, "page_size", "7"},
+		{[]string{"set", "sprite_mode", "ASCII"}, "sprite_mode", "ascii"},
+		{[]string{"set", "output_format", "Markdown"}, "output_format", "md"},
+		{[]string{"set", "output_format", "csv"}, "output_format", "csv"},
+	}
+	for _, c := range cases {
+		captureOutput(func() { commandConfigure(context.Background(), &config, c.args) })
+		s, _ := findSetting(c.setting)
+		if got := s.get(&config); got != c.expected {
+			t.Errorf("%v: expected %q, got %q", c.args, c.expected, got)
+		}
+	}
+}
+
+func TestConfigSetRejectsInvalidValues(t *testing.T) {
+	restoreSettings(t)
+	var config commandConfig
+
+	cases := [][]string{
+		{"set", "base_url", "ftp://example.com/"},
+		{"set", "cache_ttl", "-1m"},
+		{"set", "timeout", "soon"},
+		{"set", "page_size", "0"},
+		{"set", "units", "feet"},
+		{"set", "sprite_mode", "crayon"},
+		{"set", "output_format", "txt"},
+	}
+	for _, args := range cases {
+		s, _ := findSetting(args[1])
+		before := s.get(&config)
+		output := captureOutput(func() { commandConfigure(context.Background(), &config, args) })
+		if output == "" || strings.Contains(output, " = ") {
+			t.Errorf("%v: expected the value to be refused, got %q", args, output)
+		}
+		if after := s.get(&config); after != before {
+			t.Errorf("%v: expected %q to be kept, got %q", args, before, after)
+		}
+	}
+}
+
+func TestLoadSettings(t *testing.T) {
+	restoreSettings(t)
+	path := filepath.Join(t.TempDir(), "config.toml")
+	file := `cache_ttl = "30m"
+units = imperial
+colour = blue
+
+[profile.local]
+base_url = "http://localhost:8000/api/v2/"
+timeout = 5s
+`
+	if err := os.WriteFile(path, []byte(file), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("POKEDEX_CONFIG", path)
+	t.Setenv("POKEDEX_PAGE_SIZE", "7")
+
+	var config commandConfig
+	errs := loadSettings(context.Background(), &config, "local")
+	if len(errs) != 1 || !strings.Contains(errs[0].Error(), `unknown setting "colour"`) {
+		t.Errorf("expected only the unknown setting to be reported, got %v", errs)
+	}
+	if pokeapi.BaseURL != "http://localhost:8000/api/v2/" || config.options.timeout != 5*time.Second ||
+		config.options.cacheTTL != 30*time.Minute || config.options.pageSize != 7 || !config.options.imperial {
+		t.Errorf("unexpected settings %+v with base URL %v", config.options, pokeapi.BaseURL)
+	}
+
+	loadSettings(context.Background(), &config, "")
+	if pokeapi.BaseURL != pokeapi.DefaultBaseURL || config.options.timeout != defaultTimeout {
+		t.Errorf("expected the profile's settings to be reset, got %+v with base URL %v", config.options, pokeapi.BaseURL)
+	}
+	if errs := loadSettings(context.Background(), &config, "missing"); len(errs) != 1 {
+		t.Errorf("expected an error for a missing profile, got %v", errs)
+	}
+}
@@ -0,0 +1,163 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// maxMoves is how many moves a pokemon can know at once.
+const maxMoves = 4
+
+// showdownName turns an API name such as "choice-scarf" into the title case
+// Showdown uses. Showdown ignores case and punctuation when reading names, so
+// "Mr Mime" is read back as Mr. Mime.
+func showdownName(slug string) string {
+	words := strings.Split(slug, "-")
+	for i, word := range words {
+		if word != "" {
+			words[i] = strings.ToUpper(word[:1]) + word[1:]
+		}
+	}
+	return strings.Join(words, " ")
+}
+
+// showdownSlug turns a Showdown name such as "King's Shield" back into an API
+// name like "kings-shield".
+func showdownSlug(name string) string {
+	var b strings.Builder
+	dash := false
+	for _, r := range strings.ToLower(strings.TrimSpace(name)) {
+		switch {
+		case r >= 'a' && r <= 'z' || r >= '0' && r <= '9':
+			if dash && b.Len() > 0 {
+				b.WriteByte('-')
+			}
+			dash = false
+			b.WriteRune(r)
+		case r == ' ' || r == '-' || r == '_':
+			dash = true
+		}
+	}
+	return b.String()
+}
+
+// encodeShowdown writes records as a Showdown team, one set per record.
+func encodeShowdown(w io.Writer, records []exportRecord) error {
+	var b strings.Builder
+	for i, r := range records {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+		species := showdownName(r.Species)
+		if r.Nickname != "" && !strings.EqualFold(r.Nickname, species) {
+			b.WriteString(fmt.Sprintf("%v (%v)", r.Nickname, species))
+		} else {
+			b.WriteString(species)
+		}
+		if r.Item != "" {
+			b.WriteString(" @ " + showdownName(r.Item))
+		}
+		b.WriteString("\n")
+		if r.Ability != "" {
+			b.WriteString(fmt.Sprintf("Ability: %v\n", showdownName(r.Ability)))
+		}
+		if r.EVs != "" {
+			b.WriteString(fmt.Sprintf("EVs: %v\n", r.EVs))
+		}
+		if r.Nature != "" {
+			b.WriteString(fmt.Sprintf("%v Nature\n", showdownName(r.Nature)))
+		}
+		if r.IVs != "" {
+			b.WriteString(fmt.Sprintf("IVs: %v\n", r.IVs))
+		}
+		for _, move := range r.Moves {
+			b.WriteString(fmt.Sprintf("- %v\n", showdownName(move)))
+		}
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// decodeShowdown reads a Showdown team. Every set is put in the party, and
+// details this Pokedex doesn't track, such as level or tera type, are
+// skipped.
+func decodeShowdown(r io.Reader) ([]exportRecord, error) {
+	records := []exportRecord{}
+	var current *exportRecord
+	scanner := bufio.NewScanner(r)
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		switch {
+		case line == "":
+			current = nil
+			continue
+		case strings.HasPrefix(line, "==="):
+			// Team headers like "=== [gen9ou] Untitled ===".
+			current = nil
+			continue
+		case current == nil:
+			records = append(records, parseShowdownHeader(line))
+			current = &records[len(records)-1]
+			continue
+		}
+
+		key, value, hasValue := strings.Cut(line, ":")
+		value = strings.TrimSpace(value)
+		switch {
+		case strings.HasPrefix(line, "-"):
+			move := strings.TrimSpace(strings.TrimPrefix(line, "-"))
+			// Hidden Power is listed with its type, e.g. "Hidden Power [Fire]".
+			if i := strings.Index(move, "["); i >= 0 {
+				move = move[:i]
+			}
+			current.Moves = append(current.Moves, showdownSlug(move))
+		case strings.HasSuffix(line, " Nature"):
+			current.Nature = showdownSlug(strings.TrimSuffix(line, " Nature"))
+		case hasValue && key == "Ability":
+			current.Ability = showdownSlug(value)
+		case hasValue && (key == "EVs" || key == "IVs"):
+			// Showdown only lists EVs that are set and IVs that aren't
+			// perfect.
+			def := 0
+			if key == "IVs" {
+				def = maxIV
+			}
+			spread, err := parseSpread(value, def)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %w", n, err)
+			}
+			if key == "EVs" {
+				current.EVs = spread.format(def)
+			} else {
+				current.IVs = spread.format(def)
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return records, nil
+}
+
+// parseShowdownHeader reads the first line of a set, which is the species
+// with an optional nickname, gender and held item:
+//
+//	Nickname (Species) (F) @ Item
+func parseShowdownHeader(line string) exportRecord {
+	r := exportRecord{Storage: location{}.String()}
+	if name, item, ok := strings.Cut(line, " @ "); ok {
+		line = name
+		r.Item = showdownSlug(item)
+	}
+	line = strings.TrimSpace(strings.TrimSuffix(strings.TrimSuffix(line, " (M)"), " (F)"))
+	if strings.HasSuffix(line, ")") {
+		if i := strings.LastIndex(line, " ("); i > 0 {
+			r.Nickname = line[:i]
+			line = line[i+2 : len(line)-1]
+		}
+	}
+	r.Species = showdownSlug(line)
+	return r
+}
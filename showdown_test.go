@@ -0,0 +1,131 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const showdownTeam = `=== [gen9ou] Sample ===
+
+Sparky (Pikachu) (F) @ Light Ball
+Ability: Static
+Level: 50
+Tera Type: Electric
+EVs: 4 HP / 252 SpA / 252 Spe
+Timid Nature
+IVs: 0 Atk
+- Thunderbolt
+- Volt Switch
+- Hidden Power [Ice]
+- Grass Knot
+
+Mr. Mime @ King's Rock
+Ability: Filter
+- Psychic
+`
+
+func TestDecodeShowdown(t *testing.T) {
+	records, err := decodeShowdown(strings.NewReader(showdownTeam))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(records) != 2 {
+		t.Fatalf("expected 2 sets, got %+v", records)
+	}
+	pikachu := records[0]
+	if pikachu.Species != "pikachu" || pikachu.Nickname != "Sparky" || pikachu.Item != "light-ball" || pikachu.Storage != "party" {
+		t.Errorf("unexpected set %+v", pikachu)
+	}
+	if pikachu.Ability != "static" || pikachu.Nature != "timid" {
+		t.Errorf("unexpected ability or nature %+v", pikachu)
+	}
+	if pikachu.EVs != "4 HP / 252 SpA / 252 Spe" || pikachu.IVs != "0 Atk" {
+		t.Errorf("unexpected spreads %q, %q", pikachu.EVs, pikachu.IVs)
+	}
+	want := []string{"thunderbolt", "volt-switch", "hidden-power", "grass-knot"}
+	if strings.Join(pikachu.Moves, ",") != strings.Join(want, ",") {
+		t.Errorf("expected moves %v, got %v", want, pikachu.Moves)
+	}
+	if records[1].Species != "mr-mime" || records[1].Item != "kings-rock" || records[1].Nickname != "" {
+		t.Errorf("unexpected set %+v", records[1])
+	}
+}
+
+func TestShowdownRoundTrip(t *testing.T) {
+	records, err := decodeShowdown(strings.NewReader(showdownTeam))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var buf bytes.Buffer
+	if err := encodeShowdown(&buf, records); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.HasPrefix(buf.String(), "Sparky (Pikachu) @ Light Ball\nAbility: Static\nEVs: 4 HP / 252 SpA / 252 Spe\nTimid Nature\nIVs: 0 Atk\n- Thunderbolt\n") {
+		t.Errorf("unexpected team:\n%v", buf.String())
+	}
+	decoded, err := decodeShowdown(&buf)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for i := range records {
+		if !sameSet(decoded[i], records[i]) {
+			t.Errorf("expected %+v to survive a round trip, got %+v", records[i], decoded[i])
+		}
+	}
+}
+
+func TestRecordOwnedChecksSet(t *testing.T) {
+	tests := []exportRecord{
+		{Species: "pikachu", Nature: "sleepy"},
+		{Species: "pikachu", EVs: "252 HP / 252 Atk / 252 Spe"},
+		{Species: "pikachu", EVs: "300 Spe"},
+		{Species: "pikachu", IVs: "32 Atk"},
+		{Species: "pikachu", Moves: []string{"a", "b", "c", "d", "e"}},
+	}
+	for _, r := range tests {
+		if _, err := r.owned(1, newOwned(1, "pikachu").Pokemon); err == nil {
+			t.Errorf("expected an error for %+v", r)
+		}
+	}
+
+	r := exportRecord{Species: "pikachu", Nature: "timid", EVs: "252 Spe", IVs: "0 Atk"}
+	p, err := r.owned(1, newOwned(1, "pikachu").Pokemon)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if p.EVs[5] != 252 || p.ivs()[1] != 0 || p.ivs()[0] != maxIV {
+		t.Errorf("unexpected spreads %v, %v", p.EVs, p.ivs())
+	}
+}
+
+func TestImportedShowdownSetCanBeFound(t *testing.T) {
+	serveAPI(t, map[string]string{
+		"pokemon/pikachu": `{"id": 25, "name": "pikachu", "species": {"name": "pikachu"},
+			"abilities": [{"ability": {"name": "static"}}],
+			"moves": [{"move": {"name": "thunderbolt"}}]}`,
+	})
+	path := filepath.Join(t.TempDir(), "team.showdown")
+	set := "Sparky (Pikachu)\nAbility: Static\n- Thunderbolt\n"
+	if err := os.WriteFile(path, []byte(set), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	var config commandConfig
+	captureOutput(func() { commandImport(context.Background(), &config, []string{path}) })
+	if _, ok := config.storage.find("sparky"); !ok {
+		t.Fatal("expected the imported pikachu to be found by its nickname")
+	}
+	captureOutput(func() { commandTeam(context.Background(), &config, []string{"add", "sparky"}) })
+	i, ok := config.findTeamMember([]string{"sparky"})
+	if !ok || config.team[i].Nickname != "Sparky" {
+		t.Fatalf("expected Sparky on the team, got %+v", config.team)
+	}
+	captureOutput(func() { commandTeam(context.Background(), &config, []string{"remove", "sparky"}) })
+	if len(config.team) != 0 {
+		t.Errorf("expected Sparky to be removed from the team, got %+v", config.team)
+	}
+}
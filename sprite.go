@@ -0,0 +1,167 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"image"
+	_ "image/gif"
+	_ "image/png"
+	"strconv"
+
+	"github.com/zorahscope/pokedexcli/internal/pokeapi"
+	"github.com/zorahscope/pokedexcli/internal/termimg"
+)
+
+const defaultSpriteWidth = 64
+
+// spriteVariant selects which of a pokemon's sprites to show.
+type spriteVariant struct {
+	generation string
+	shiny      bool
+	back       bool
+}
+
+// spriteURL picks the sprite URL for the variant. Generations are given as
+// roman numerals, or as "artwork" for the official artwork; an empty
+// generation uses the default sprite.
+func spriteURL(pkmn pokeapi.Pokemon, v spriteVariant) (string, error) {
+	sprites := pkmn.Sprites
+	versions := sprites.Versions
+	pick := func(front, frontShiny, back, backShiny string) string {
+		switch {
+		case v.back && v.shiny:
+			return backShiny
+		case v.back:
+			return back
+		case v.shiny:
+			return frontShiny
+		}
+		return front
+	}
+
+	var url string
+	switch v.generation {
+	case "":
+		url = pick(sprites.FrontDefault, sprites.FrontShiny, sprites.BackDefault, sprites.BackShiny)
+	case "artwork":
+		url = pick(sprites.Other.OfficialArtwork.FrontDefault, sprites.Other.OfficialArtwork.FrontShiny, "", "")
+	case "i":
+		s := versions.GenerationI.RedBlue
+		url = pick(s.FrontDefault, "", s.BackDefault, "")
+	case "ii":
+		s := versions.GenerationIi.Crystal
+		url = pick(s.FrontDefault, s.FrontShiny, s.BackDefault, s.BackShiny)
+	case "iii":
+		s := versions.GenerationIii.RubySapphire
+		url = pick(s.FrontDefault, s.FrontShiny, s.BackDefault, s.BackShiny)
+	case "iv":
+		s := versions.GenerationIv.Platinum
+		url = pick(s.FrontDefault, s.FrontShiny, s.BackDefault, s.BackShiny)
+	case "v":
+		s := versions.GenerationV.BlackWhite
+		url = pick(s.FrontDefault, s.FrontShiny, s.BackDefault, s.BackShiny)
+	case "vi":
+		s := versions.GenerationVi.XY
+		url = pick(s.FrontDefault, s.FrontShiny, "", "")
+	case "vii":
+		s := versions.GenerationVii.UltraSunUltraMoon
+		url = pick(s.FrontDefault, s.FrontShiny, "", "")
+	case "viii":
+		url = pick(versions.GenerationViii.Icons.FrontDefault, "", "", "")
+	default:
+		return "", fmt.Errorf("unknown sprite generation %q, expected i through viii or artwork", v.generation)
+	}
+	if url == "" {
+		return "", fmt.Errorf("%v has no sprite for that variant", pkmn.Name)
+	}
+	return url, nil
+}
+
+// fetchSprite downloads and decodes the sprite image for the variant.
+func fetchSprite(ctx context.Context, pkmn pokeapi.Pokemon, v spriteVariant) (image.Image, error) {
+	url, err := spriteURL(pkmn, v)
+	if err != nil {
+		return nil, err
+	}
+	data, err := pokeapi.GetRaw(ctx, url)
+	if err != nil {
+		return nil, fmt.Errorf("error getting sprite: %w", err)
+	}
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("error decoding sprite: %w", err)
+	}
+	return img, nil
+}
+
+// drawSprite renders img with the inline image protocol the terminal
+// supports, falling back to block art. The --mode flag forces a specific
+// protocol or block art mode, as does defaultMode when the flag isn't given.
+func drawSprite(img image.Image, flags commandFlags, defaultMode string) (string, error) {
+	width, err := strconv.Atoi(flags.get("width", strconv.Itoa(defaultSpriteWidth)))
+	if err != nil || width < 1 {
+		return "", fmt.Errorf("width must be a positive number")
+	}
+	img = termimg.Crop(img)
+
+	name, forced := flags["mode"]
+	if !forced && defaultMode != "" {
+		name, forced = defaultMode, true
+	}
+	if !forced {
+		if protocol := termimg.DetectProtocol(); protocol != termimg.NoProtocol {
+			return termimg.Inline(img, protocol, width)
+		}
+		return termimg.Render(img, termimg.DetectMode(), width), nil
+	}
+	if protocol, ok := termimg.ParseProtocol(name); ok {
+		return termimg.Inline(img, protocol, width)
+	}
+	mode, err := termimg.ParseMode(name)
+	if err != nil {
+		return "", err
+	}
+	return termimg.Render(img, mode, width), nil
+}
+
+// spriteVariantFlags reads the sprite variant from the --gen, --shiny and
+// --back flags.
+func spriteVariantFlags(flags commandFlags) spriteVariant {
+	return spriteVariant{
+		generation: flags.get("gen", ""),
+		shiny:      flags.has("shiny"),
+		back:       flags.has("back"),
+	}
+}
+
+func commandSprite(ctx context.Context, config *commandConfig, args []string) error {
+	args, flags := parseFlags(args, "gen", "mode", "width")
+	if len(args) == 0 {
+		fmt.Println("No pokemon selected! Please try again")
+		return nil
+	}
+
+	pkmn, err := lookupPokemon(ctx, config, args[0])
+	if errors.Is(err, pokeapi.ErrNotFound) {
+		fmt.Println(err)
+		return nil
+	}
+	if err != nil {
+		fmt.Println(err)
+		return err
+	}
+	img, err := fetchSprite(ctx, pkmn, spriteVariantFlags(flags))
+	if err != nil {
+		fmt.Println(err)
+		return err
+	}
+	sprite, err := drawSprite(img, flags, config.options.spriteMode)
+	if err != nil {
+		fmt.Println(err)
+		return nil
+	}
+	fmt.Print(sprite)
+	return nil
+}
@@ -0,0 +1,210 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/zorahscope/pokedexcli/internal/pokeapi"
+)
+
+const (
+	maxEV       = 252
+	maxTotalEVs = 510
+	maxIV       = 31
+)
+
+// statNames are the API's names for the six stats, in the order games and
+// Showdown list them.
+var statNames = [6]string{"hp", "attack", "defense", "special-attack", "special-defense", "speed"}
+
+// statAbbrevs are the short names Showdown uses for statNames.
+var statAbbrevs = [6]string{"HP", "Atk", "Def", "SpA", "SpD", "Spe"}
+
+// statSpread holds a value, such as EVs or IVs, for each stat in statNames
+// order.
+type statSpread [6]int
+
+func perfectIVs() statSpread {
+	return statSpread{maxIV, maxIV, maxIV, maxIV, maxIV, maxIV}
+}
+
+func (s statSpread) total() int {
+	total := 0
+	for _, v := range s {
+		total += v
+	}
+	return total
+}
+
+// format writes the stats that differ from def like "252 HP / 4 Atk".
+func (s statSpread) format(def int) string {
+	parts := []string{}
+	for i, v := range s {
+		if v != def {
+			parts = append(parts, fmt.Sprintf("%d %v", v, statAbbrevs[i]))
+		}
+	}
+	return strings.Join(parts, " / ")
+}
+
+// parseSpread reads a spread written by format, with unlisted stats set to
+// def. Stats can be given by their Showdown abbreviation or API name.
+func parseSpread(text string, def int) (statSpread, error) {
+	spread := statSpread{def, def, def, def, def, def}
+	if strings.TrimSpace(text) == "" {
+		return spread, nil
+	}
+	for _, part := range strings.Split(text, "/") {
+		fields := strings.Fields(part)
+		if len(fields) != 2 {
+			return spread, fmt.Errorf("%q should be a number and a stat, like 252 Spe", strings.TrimSpace(part))
+		}
+		value, err := strconv.Atoi(fields[0])
+		if err != nil || value < 0 {
+			return spread, fmt.Errorf("%q isn't a stat value", fields[0])
+		}
+		i := statIndex(fields[1])
+		if i < 0 {
+			return spread, fmt.Errorf("unknown stat %q", fields[1])
+		}
+		spread[i] = value
+	}
+	return spread, nil
+}
+
+func statIndex(name string) int {
+	for i := range statNames {
+		if strings.EqualFold(name, statAbbrevs[i]) || strings.EqualFold(name, statNames[i]) {
+			return i
+		}
+	}
+	return -1
+}
+
+// validEVs checks EVs against the per-stat and total limits.
+func validEVs(evs statSpread) error {
+	for i, v := range evs {
+		if v > maxEV {
+			return fmt.Errorf("%d %v EVs is over the limit of %d", v, statAbbrevs[i], maxEV)
+		}
+	}
+	if total := evs.total(); total > maxTotalEVs {
+		return fmt.Errorf("%d EVs in total is over the limit of %d", total, maxTotalEVs)
+	}
+	return nil
+}
+
+func validIVs(ivs statSpread) error {
+	for i, v := range ivs {
+		if v > maxIV {
+			return fmt.Errorf("%d %v IVs is over the limit of %d", v, statAbbrevs[i], maxIV)
+		}
+	}
+	return nil
+}
+
+// nature raises one stat by 10% and lowers another. Neutral natures have
+// neither set.
+type nature struct {
+	up   string
+	down string
+}
+
+var natures = map[string]nature{
+	"hardy":   {},
+	"lonely":  {"attack", "defense"},
+	"brave":   {"attack", "speed"},
+	"adamant": {"attack", "special-attack"},
+	"naughty": {"attack", "special-defense"},
+	"bold":    {"defense", "attack"},
+	"docile":  {},
+	"relaxed": {"defense", "speed"},
+	"impish":  {"defense", "special-attack"},
+	"lax":     {"defense", "special-defense"},
+	"timid":   {"speed", "attack"},
+	"hasty":   {"speed", "defense"},
+	"serious": {},
+	"jolly":   {"speed", "special-attack"},
+	"naive":   {"speed", "special-defense"},
+	"modest":  {"special-attack", "attack"},
+	"mild":    {"special-attack", "defense"},
+	"quiet":   {"special-attack", "speed"},
+	"bashful": {},
+	"rash":    {"special-attack", "special-defense"},
+	"calm":    {"special-defense", "attack"},
+	"gentle":  {"special-defense", "defense"},
+	"sassy":   {"special-defense", "speed"},
+	"careful": {"special-defense", "special-attack"},
+	"quirky":  {},
+}
+
+// baseStats returns a pokemon's base stats in statNames order.
+func baseStats(pkmn pokeapi.Pokemon) statSpread {
+	var stats statSpread
+	for _, s := range pkmn.Stats {
+		if i := statIndex(s.Stat.Name); i >= 0 {
+			stats[i] = s.BaseStat
+		}
+	}
+	return stats
+}
+
+// maxBaseStat is the highest a base stat can be, which stat bars are scaled
+// to.
+const maxBaseStat = 255
+
+// statBar draws a base stat as a bar width characters long at most, using
+// eighth blocks so small differences still show.
+func statBar(value, width int) string {
+	eighths := min(value, maxBaseStat) * width * 8 / maxBaseStat
+	bar := strings.Repeat("█", eighths/8)
+	if rem := eighths % 8; rem > 0 {
+		bar += string([]rune("▏▎▍▌▋▊▉")[rem-1])
+	}
+	return bar
+}
+
+// natureEffect returns the percentage a nature scales the stat at index i
+// by: 110 when it's raised, 90 when it's lowered and 100 otherwise.
+func natureEffect(n nature, i int) int {
+	switch statNames[i] {
+	case n.up:
+		return 110
+	case n.down:
+		return 90
+	}
+	return 100
+}
+
+// calcStat works out the stat at index i at level from its base stat, IV and
+// EV, using the formula games have used since generation 3. percent is the
+// nature's effect from natureEffect.
+func calcStat(i, base, iv, ev, level, percent int) int {
+	value := (2*base + iv + ev/4) * level / 100
+	if statNames[i] != "hp" {
+		return (value + 5) * percent / 100
+	}
+	// Shedinja always has 1 HP.
+	if base == 1 {
+		return 1
+	}
+	return value + level + 10
+}
+
+// statRange returns the lowest and highest the stat at index i can be at
+// level, from no IVs or EVs to perfect IVs and the most EVs.
+func statRange(i, base, level, percent int) (int, int) {
+	return calcStat(i, base, 0, 0, level, percent), calcStat(i, base, maxIV, maxEV, level, percent)
+}
+
+// evYield returns the EVs defeating a pokemon gives, like "2 attack".
+func evYield(pkmn pokeapi.Pokemon) string {
+	yield := []string{}
+	for _, s := range pkmn.Stats {
+		if s.Effort > 0 {
+			yield = append(yield, fmt.Sprintf("%d %v", s.Effort, s.Stat.Name))
+		}
+	}
+	return strings.Join(yield, ", ")
+}
@@ -0,0 +1,73 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/zorahscope/pokedexcli/internal/pokeapi"
+)
+
+func TestStatRange(t *testing.T) {
+	// Garchomp: 108 HP, 130 Attack, 102 Speed.
+	tests := []struct {
+		stat, base, level, percent int
+		low, high                  int
+	}{
+		{0, 108, 100, 100, 326, 420},
+		{0, 108, 50, 100, 168, 215},
+		{1, 130, 100, 100, 265, 359},
+		{1, 130, 100, 110, 291, 394},
+		{1, 130, 100, 90, 238, 323},
+		{5, 102, 50, 110, 117, 169},
+		{0, 1, 100, 100, 1, 1},
+	}
+	for _, tt := range tests {
+		low, high := statRange(tt.stat, tt.base, tt.level, tt.percent)
+		if low != tt.low || high != tt.high {
+			t.Errorf("%v base %d at level %d (%d%%): expected %d-%d, got %d-%d",
+				statNames[tt.stat], tt.base, tt.level, tt.percent, tt.low, tt.high, low, high)
+		}
+	}
+}
+
+func TestNatureEffect(t *testing.T) {
+	timid := natures["timid"]
+	if natureEffect(timid, 5) != 110 || natureEffect(timid, 1) != 90 || natureEffect(timid, 3) != 100 {
+		t.Errorf("expected timid to raise speed and lower attack")
+	}
+	if natureEffect(natures["hardy"], 1) != 100 {
+		t.Errorf("expected hardy to be neutral")
+	}
+}
+
+func TestStatBar(t *testing.T) {
+	tests := []struct {
+		value, width int
+		want         string
+	}{
+		{0, 10, ""},
+		{255, 4, "████"},
+		{300, 4, "████"},
+		{51, 10, "██"},
+		{60, 10, "██▎"},
+	}
+	for _, tt := range tests {
+		if got := statBar(tt.value, tt.width); got != tt.want {
+			t.Errorf("statBar(%d, %d) = %q, expected %q", tt.value, tt.width, got, tt.want)
+		}
+	}
+}
+
+func TestBaseStats(t *testing.T) {
+	pkmn := newOwned(1, "pikachu").Pokemon
+	for i, name := range statNames {
+		pkmn.Stats = append(pkmn.Stats, struct {
+			BaseStat int                       `json:"base_stat"`
+			Effort   int                       `json:"effort"`
+			Stat     pokeapi.Ref[pokeapi.Stat] `json:"stat"`
+		}{BaseStat: 10 * (i + 1), Stat: pokeapi.Ref[pokeapi.Stat]{Name: name}})
+	}
+	stats := baseStats(pkmn)
+	if stats != (statSpread{10, 20, 30, 40, 50, 60}) || stats.total() != 210 {
+		t.Errorf("unexpected base stats %v", stats)
+	}
+}
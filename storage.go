@@ -0,0 +1,165 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+const (
+	partySize   = 6
+	boxCount    = 8
+	boxCapacity = 30
+)
+
+var errStorageFull = errors.New("the party and every PC box are full")
+
+// location identifies where an owned pokemon is kept. Box 0 is the party,
+// boxes 1 through boxCount are the PC boxes.
+type location struct {
+	box   int
+	index int
+}
+
+func (l location) String() string {
+	if l.box == 0 {
+		return "party"
+	}
+	return fmt.Sprintf("box %d", l.box)
+}
+
+// storage holds every pokemon the trainer owns, split between the party and
+// the numbered PC boxes.
+type storage struct {
+	party []ownedPokemon
+	boxes [boxCount][]ownedPokemon
+}
+
+func (s *storage) slots(box int) *[]ownedPokemon {
+	if box == 0 {
+		return &s.party
+	}
+	return &s.boxes[box-1]
+}
+
+func capacity(box int) int {
+	if box == 0 {
+		return partySize
+	}
+	return boxCapacity
+}
+
+func validBox(box int) bool {
+	return box >= 1 && box <= boxCount
+}
+
+// all returns every owned pokemon, party first and then each box in order.
+func (s *storage) all() []ownedPokemon {
+	all := append([]ownedPokemon{}, s.party...)
+	for _, box := range s.boxes {
+		all = append(all, box...)
+	}
+	return all
+}
+
+// find looks up an owned pokemon by nickname or species name, preferring the
+// party over the boxes.
+func (s *storage) find(name string) (location, bool) {
+	return s.findIn(name, 0, boxCount)
+}
+
+// findIn looks up an owned pokemon by nickname or species name within boxes
+// first through last, where box 0 is the party. Nicknames are matched
+// ignoring case, since input is lowercased but imported nicknames needn't be.
+func (s *storage) findIn(name string, first, last int) (location, bool) {
+	for box := first; box <= last; box++ {
+		for i, owned := range *s.slots(box) {
+			if strings.EqualFold(owned.Nickname, name) || owned.Pokemon.Name == name {
+				return location{box: box, index: i}, true
+			}
+		}
+	}
+	return location{}, false
+}
+
+// findID looks up an owned pokemon by its unique id.
+func (s *storage) findID(id int) (location, bool) {
+	for box := 0; box <= boxCount; box++ {
+		for i, owned := range *s.slots(box) {
+			if owned.ID == id {
+				return location{box: box, index: i}, true
+			}
+		}
+	}
+	return location{}, false
+}
+
+func (s *storage) get(loc location) *ownedPokemon {
+	return &(*s.slots(loc.box))[loc.index]
+}
+
+func (s *storage) remove(loc location) ownedPokemon {
+	slots := s.slots(loc.box)
+	owned := (*slots)[loc.index]
+	*slots = append((*slots)[:loc.index], (*slots)[loc.index+1:]...)
+	return owned
+}
+
+// insert places a pokemon at loc, clamping the index to the end of the box.
+// It does not check capacity and is intended for putting back a pokemon that
+// was just removed.
+func (s *storage) insert(loc location, owned ownedPokemon) {
+	slots := s.slots(loc.box)
+	if loc.index > len(*slots) {
+		loc.index = len(*slots)
+	}
+	*slots = append((*slots)[:loc.index], append([]ownedPokemon{owned}, (*slots)[loc.index:]...)...)
+}
+
+func (s *storage) full(box int) bool {
+	return len(*s.slots(box)) >= capacity(box)
+}
+
+// hasRoom reports whether there's room for another pokemon anywhere.
+func (s *storage) hasRoom() bool {
+	for box := 0; box <= boxCount; box++ {
+		if !s.full(box) {
+			return true
+		}
+	}
+	return false
+}
+
+// add stores a newly caught pokemon in the party, or in the first PC box with
+// room once the party is full.
+func (s *storage) add(owned ownedPokemon) (location, error) {
+	for box := 0; box <= boxCount; box++ {
+		if !s.full(box) {
+			slots := s.slots(box)
+			*slots = append(*slots, owned)
+			return location{box: box, index: len(*slots) - 1}, nil
+		}
+	}
+	return location{}, errStorageFull
+}
+
+// move takes the pokemon at from and appends it to box.
+func (s *storage) move(from location, box int) (location, error) {
+	if s.full(box) {
+		return location{}, fmt.Errorf("%v is full", location{box: box})
+	}
+	owned := s.remove(from)
+	slots := s.slots(box)
+	*slots = append(*slots, owned)
+	return location{box: box, index: len(*slots) - 1}, nil
+}
+
+// firstOpenBox returns the first PC box that has room.
+func (s *storage) firstOpenBox() (int, bool) {
+	for box := 1; box <= boxCount; box++ {
+		if !s.full(box) {
+			return box, true
+		}
+	}
+	return 0, false
+}
@@ -0,0 +1,55 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/zorahscope/pokedexcli/internal/pokeapi"
+)
+
+func newOwned(id int, name string) ownedPokemon {
+	return ownedPokemon{ID: id, Pokemon: pokeapi.Pokemon{Name: name}}
+}
+
+func TestStorageAddRoutesToBox(t *testing.T) {
+	var s storage
+	for i := 1; i <= partySize+1; i++ {
+		loc, err := s.add(newOwned(i, "pidgey"))
+		if err != nil {
+			t.Fatalf("unexpected error adding pokemon %d: %v", i, err)
+		}
+		expectedBox := 0
+		if i > partySize {
+			expectedBox = 1
+		}
+		if loc.box != expectedBox {
+			t.Errorf("pokemon %d stored in box %d, expected %d", i, loc.box, expectedBox)
+		}
+	}
+	if len(s.all()) != partySize+1 {
+		t.Errorf("all() returned %d pokemon, expected %d", len(s.all()), partySize+1)
+	}
+}
+
+func TestStorageMoveAndInsert(t *testing.T) {
+	var s storage
+	s.add(newOwned(1, "pidgey"))
+	s.add(newOwned(2, "rattata"))
+
+	from, ok := s.find("pidgey")
+	if !ok {
+		t.Fatal("expected to find pidgey")
+	}
+	to, err := s.move(from, 3)
+	if err != nil {
+		t.Fatalf("unexpected error moving pidgey: %v", err)
+	}
+	if to.box != 3 || len(s.party) != 1 {
+		t.Errorf("pidgey was not moved to box 3")
+	}
+
+	owned := s.remove(to)
+	s.insert(from, owned)
+	if loc, ok := s.findID(1); !ok || loc != from {
+		t.Errorf("pidgey was not put back at %v", from)
+	}
+}
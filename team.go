@@ -0,0 +1,314 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/zorahscope/pokedexcli/internal/pokeapi"
+)
+
+const teamSize = partySize
+
+// commandTeam manages the team being built. Team members are copies, so
+// pokemon that haven't been caught can be planned for, and changing a
+// member's moves doesn't change the pokemon in your collection.
+func commandTeam(ctx context.Context, config *commandConfig, args []string) error {
+	if len(args) == 0 {
+		return showTeam(ctx, config)
+	}
+	switch args[0] {
+	case "show":
+		return showTeam(ctx, config)
+	case "add":
+		return teamAdd(ctx, config, args[1:])
+	case "remove":
+		i, ok := config.findTeamMember(args[1:])
+		if !ok {
+			return nil
+		}
+		removed := config.team[i]
+		config.team = slices.Delete(config.team, i, i+1)
+		config.dirty = true
+		fmt.Printf("%v was removed from your team\n", removed.displayName())
+	case "moves":
+		return teamMoves(config, args[1:])
+	case "party":
+		config.team = slices.Clone(config.storage.party)
+		config.dirty = true
+		fmt.Printf("Your team is now your party of %d\n", len(config.team))
+	case "clear":
+		config.team = nil
+		config.dirty = true
+		fmt.Println("Your team was cleared")
+	default:
+		fmt.Println("usage: team [show | add <pokemon> | remove <pokemon> | moves <pokemon> [move...] | party | clear]")
+	}
+	return nil
+}
+
+// findTeamMember looks up a team member by nickname, ignoring case like
+// storage.find, or species name and prints a message when it can't be found.
+func (config *commandConfig) findTeamMember(args []string) (int, bool) {
+	if len(args) == 0 {
+		fmt.Println("No pokemon selected! Please try again")
+		return 0, false
+	}
+	for i, member := range config.team {
+		if strings.EqualFold(member.Nickname, args[0]) || member.Pokemon.Name == args[0] {
+			return i, true
+		}
+	}
+	fmt.Printf("%v is not on your team\n", args[0])
+	return 0, false
+}
+
+// teamAdd adds a pokemon to the team. Pokemon you own are added with their
+// nickname and moves; any other pokemon is looked up by name.
+func teamAdd(ctx context.Context, config *commandConfig, args []string) error {
+	if len(args) == 0 {
+		fmt.Println("No pokemon selected! Please try again")
+		return nil
+	}
+	if len(config.team) >= teamSize {
+		fmt.Printf("Your team already has %d pokemon, remove one first\n", teamSize)
+		return nil
+	}
+	var member ownedPokemon
+	if loc, ok := config.storage.find(args[0]); ok {
+		member = *config.storage.get(loc)
+		member.Moves = slices.Clone(member.Moves)
+	} else {
+		pkmn, err := lookupPokemon(ctx, config, args[0])
+		if errors.Is(err, pokeapi.ErrNotFound) {
+			fmt.Println(err)
+			return nil
+		}
+		if err != nil {
+			fmt.Println(err)
+			return err
+		}
+		member = ownedPokemon{Pokemon: pkmn}
+	}
+	config.team = append(config.team, member)
+	config.dirty = true
+	fmt.Printf("%v joined your team (%d/%d)\n", member.displayName(), len(config.team), teamSize)
+	return nil
+}
+
+// teamMoves sets the moves of a team member, or clears them when none are
+// given.
+func teamMoves(config *commandConfig, args []string) error {
+	i, ok := config.findTeamMember(args)
+	if !ok {
+		return nil
+	}
+	member := &config.team[i]
+	moves := args[1:]
+	if len(moves) > maxMoves {
+		fmt.Printf("a pokemon can only know %d moves\n", maxMoves)
+		return nil
+	}
+	for _, move := range moves {
+		if !canLearn(member.Pokemon, move) {
+			fmt.Printf("%v can't learn %v\n", member.Pokemon.Name, move)
+			return nil
+		}
+	}
+	member.Moves = slices.Clone(moves)
+	config.dirty = true
+	if len(moves) == 0 {
+		fmt.Printf("%v's moves were cleared\n", member.displayName())
+		return nil
+	}
+	fmt.Printf("%v knows %v\n", member.displayName(), strings.Join(moves, ", "))
+	return nil
+}
+
+// memberTypes returns a pokemon's types, as they were in the session's game
+// version when one is set.
+func (config *commandConfig) memberTypes(pkmn pokeapi.Pokemon) []string {
+	if config.version != nil {
+		return typesIn(pkmn, config.version.generation)
+	}
+	return pokemonTypes(pkmn)
+}
+
+// attackTypes returns the types of a member's damaging moves. Members without
+// moves are assumed to attack with their own types.
+func attackTypes(member ownedPokemon, types []string, moves map[string]pokeapi.Move) []string {
+	if len(member.Moves) == 0 {
+		return types
+	}
+	attacks := []string{}
+	for _, name := range member.Moves {
+		move, ok := moves[name]
+		if !ok || move.DamageClass.Name == "status" || move.Power == nil {
+			continue
+		}
+		if !slices.Contains(attacks, move.Type.Name) {
+			attacks = append(attacks, move.Type.Name)
+		}
+	}
+	return attacks
+}
+
+// fetchTeamMoves fetches every move known by a team member.
+func fetchTeamMoves(ctx context.Context, team []ownedPokemon) (map[string]pokeapi.Move, error) {
+	names := []string{}
+	urls := []string{}
+	for _, member := range team {
+		for _, move := range member.Moves {
+			if !slices.Contains(names, move) {
+				names = append(names, move)
+				urls = append(urls, pokeapi.BaseURL+"move/"+move)
+			}
+		}
+	}
+	moves, err := pokeapi.GetAll[pokeapi.Move](ctx, pokeapi.DefaultClient, urls)
+	if err != nil {
+		return nil, err
+	}
+	byName := make(map[string]pokeapi.Move, len(moves))
+	for i, move := range moves {
+		byName[names[i]] = move
+	}
+	return byName, nil
+}
+
+// teamCoverage is the type analysis of a team.
+type teamCoverage struct {
+	// offense lists, for each defending type, the members with a move that's
+	// super effective against it.
+	offense map[string][]string
+	// weak and resist list, for each attacking type, the members it's super
+	// effective or not very effective against, including immunities.
+	weak   map[string][]string
+	resist map[string][]string
+}
+
+// analyzeTeam works out a team's coverage. members are the display names of
+// the team, with the types of each member and of its attacks.
+func analyzeTeam(chart typeChart, members []string, types, attacks [][]string) teamCoverage {
+	coverage := teamCoverage{
+		offense: make(map[string][]string),
+		weak:    make(map[string][]string),
+		resist:  make(map[string][]string),
+	}
+	for i, member := range members {
+		for _, defending := range battleTypes {
+			for _, attacking := range attacks[i] {
+				if chart.effectiveness(attacking, []string{defending}) > 1 {
+					coverage.offense[defending] = append(coverage.offense[defending], member)
+					break
+				}
+			}
+		}
+		for _, attacking := range battleTypes {
+			switch m := chart.effectiveness(attacking, types[i]); {
+			case m > 1:
+				coverage.weak[attacking] = append(coverage.weak[attacking], member)
+			case m < 1:
+				coverage.resist[attacking] = append(coverage.resist[attacking], member)
+			}
+		}
+	}
+	return coverage
+}
+
+// uncovered returns the types no member hits super effectively.
+func (c teamCoverage) uncovered() []string {
+	types := []string{}
+	for _, t := range battleTypes {
+		if len(c.offense[t]) == 0 {
+			types = append(types, t)
+		}
+	}
+	return types
+}
+
+// sharedWeaknesses returns the attacking types more than one member is weak
+// to and that fewer members resist.
+func (c teamCoverage) sharedWeaknesses() []string {
+	types := []string{}
+	for _, t := range battleTypes {
+		if weak := len(c.weak[t]); weak > 1 && weak > len(c.resist[t]) {
+			types = append(types, t)
+		}
+	}
+	return types
+}
+
+func showTeam(ctx context.Context, config *commandConfig) error {
+	fmt.Printf("Your Team (%d/%d):\n", len(config.team), teamSize)
+	if len(config.team) == 0 {
+		fmt.Println("  - <empty>, add pokemon with `team add <pokemon>` or `team party`")
+		return nil
+	}
+
+	chart, err := fetchTypeChart(ctx)
+	if err != nil {
+		fmt.Println(err)
+		return fmt.Errorf("error getting data from API: %w", err)
+	}
+	moves, err := fetchTeamMoves(ctx, config.team)
+	if err != nil {
+		fmt.Println(err)
+		return fmt.Errorf("error getting data from API: %w", err)
+	}
+	printTeam(config, chart, moves)
+	return nil
+}
+
+func printTeam(config *commandConfig, chart typeChart, moves map[string]pokeapi.Move) {
+	members := make([]string, len(config.team))
+	types := make([][]string, len(config.team))
+	attacks := make([][]string, len(config.team))
+	for i, member := range config.team {
+		members[i] = member.displayName()
+		types[i] = config.memberTypes(member.Pokemon)
+		attacks[i] = attackTypes(member, types[i], moves)
+		known := "no moves set, assuming attacks of its own types"
+		if len(member.Moves) > 0 {
+			known = strings.Join(member.Moves, ", ")
+		}
+		fmt.Printf("  %d. %v [%v]: %v\n", i+1, members[i], strings.Join(types[i], "/"), known)
+	}
+	coverage := analyzeTeam(chart, members, types, attacks)
+	uncovered := coverage.uncovered()
+	shared := coverage.sharedWeaknesses()
+
+	fmt.Println("\nOffensive coverage:")
+	fmt.Printf("  %-10v %v\n", "Type", "Super effective from")
+	for _, t := range battleTypes {
+		from := strings.Join(coverage.offense[t], ", ")
+		if slices.Contains(uncovered, t) {
+			from = "<- uncovered"
+		}
+		fmt.Printf("  %-10v %v\n", t, from)
+	}
+
+	fmt.Println("\nDefensive weaknesses:")
+	fmt.Printf("  %-10v %4v %6v  %v\n", "Type", "Weak", "Resist", "Weak members")
+	for _, t := range battleTypes {
+		weak := strings.Join(coverage.weak[t], ", ")
+		if slices.Contains(shared, t) {
+			weak += "   <- shared weakness"
+		}
+		fmt.Printf("  %-10v %4d %6d  %v\n", t, len(coverage.weak[t]), len(coverage.resist[t]), weak)
+	}
+
+	fmt.Println()
+	if len(uncovered) == 0 {
+		fmt.Println("Your team hits every type super effectively")
+	} else {
+		fmt.Printf("Uncovered types: %v\n", strings.Join(uncovered, ", "))
+	}
+	if len(shared) == 0 {
+		fmt.Println("No shared weaknesses")
+	} else {
+		fmt.Printf("Shared weaknesses: %v\n", strings.Join(shared, ", "))
+	}
+}
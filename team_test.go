@@ -0,0 +1,83 @@
+package main
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/zorahscope/pokedexcli/internal/pokeapi"
+)
+
+// testChart is the part of the type chart the team tests use.
+func testChart() typeChart {
+	relations := map[string]pokeapi.TypeRelations{
+		"fire": {
+			DoubleDamageTo: []pokeapi.Result{{Name: "grass"}, {Name: "ice"}, {Name: "bug"}, {Name: "steel"}},
+			HalfDamageTo:   []pokeapi.Result{{Name: "fire"}, {Name: "water"}, {Name: "rock"}, {Name: "dragon"}},
+		},
+		"water": {
+			DoubleDamageTo: []pokeapi.Result{{Name: "fire"}, {Name: "ground"}, {Name: "rock"}},
+			HalfDamageTo:   []pokeapi.Result{{Name: "water"}, {Name: "grass"}, {Name: "dragon"}},
+		},
+		"ground": {
+			DoubleDamageTo: []pokeapi.Result{{Name: "fire"}, {Name: "electric"}, {Name: "poison"}, {Name: "rock"}, {Name: "steel"}},
+			HalfDamageTo:   []pokeapi.Result{{Name: "grass"}, {Name: "bug"}},
+			NoDamageTo:     []pokeapi.Result{{Name: "flying"}},
+		},
+		"rock": {
+			DoubleDamageTo: []pokeapi.Result{{Name: "fire"}, {Name: "ice"}, {Name: "flying"}, {Name: "bug"}},
+			HalfDamageTo:   []pokeapi.Result{{Name: "fighting"}, {Name: "ground"}, {Name: "steel"}},
+		},
+	}
+	chart := typeChart{}
+	for name, r := range relations {
+		chart[name] = damageTo(r)
+	}
+	return chart
+}
+
+func TestEffectiveness(t *testing.T) {
+	chart := testChart()
+	tests := []struct {
+		attacking string
+		defending []string
+		want      float64
+	}{
+		{"rock", []string{"fire", "flying"}, 4},
+		{"ground", []string{"fire", "flying"}, 0},
+		{"water", []string{"water", "dragon"}, 0.25},
+		{"fire", []string{"normal"}, 1},
+	}
+	for _, tt := range tests {
+		if got := chart.effectiveness(tt.attacking, tt.defending); got != tt.want {
+			t.Errorf("%v against %v: expected %v, got %v", tt.attacking, tt.defending, tt.want, got)
+		}
+	}
+}
+
+func TestAnalyzeTeam(t *testing.T) {
+	members := []string{"charizard", "arcanine", "squirtle"}
+	types := [][]string{{"fire", "flying"}, {"fire"}, {"water"}}
+	attacks := [][]string{{"fire"}, {"fire"}, {"water"}}
+	coverage := analyzeTeam(testChart(), members, types, attacks)
+
+	if got := coverage.offense["grass"]; !slices.Equal(got, []string{"charizard", "arcanine"}) {
+		t.Errorf("expected both fire types to cover grass, got %v", got)
+	}
+	if got := coverage.offense["rock"]; !slices.Equal(got, []string{"squirtle"}) {
+		t.Errorf("expected squirtle to cover rock, got %v", got)
+	}
+	if uncovered := coverage.uncovered(); !slices.Contains(uncovered, "normal") || slices.Contains(uncovered, "fire") {
+		t.Errorf("unexpected uncovered types %v", uncovered)
+	}
+
+	if got := coverage.weak["rock"]; !slices.Equal(got, []string{"charizard", "arcanine"}) {
+		t.Errorf("expected both fire types to be weak to rock, got %v", got)
+	}
+	if got := coverage.resist["ground"]; !slices.Equal(got, []string{"charizard"}) {
+		t.Errorf("expected charizard to be immune to ground, got %v", got)
+	}
+	shared := coverage.sharedWeaknesses()
+	if !slices.Equal(shared, []string{"water", "rock"}) {
+		t.Errorf("expected shared weaknesses to water and rock, got %v", shared)
+	}
+}
@@ -0,0 +1,28 @@
+//go:build !(linux || darwin || freebsd || netbsd || openbsd)
+
+package main
+
+import (
+	"errors"
+	"os"
+)
+
+var errNoRawMode = errors.New("full-screen mode isn't supported on this platform")
+
+type terminalState struct{}
+
+func makeRaw(fd int) (*terminalState, error) {
+	return nil, errNoRawMode
+}
+
+func restoreTerminal(fd int, state *terminalState) error {
+	return errNoRawMode
+}
+
+func terminalSize(fd int) (int, int, error) {
+	return 0, 0, errNoRawMode
+}
+
+func notifyResize() (<-chan os.Signal, func()) {
+	return make(chan os.Signal), func() {}
+}
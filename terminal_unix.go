@@ -0,0 +1,66 @@
+//go:build linux || darwin || freebsd || netbsd || openbsd
+
+package main
+
+import (
+	"os"
+	"os/signal"
+	"syscall"
+	"unsafe"
+)
+
+// terminalState holds the terminal settings to restore when leaving raw mode.
+type terminalState struct {
+	termios syscall.Termios
+}
+
+func ioctl(fd int, request uintptr, arg unsafe.Pointer) error {
+	_, _, errno := syscall.Syscall(syscall.SYS_IOCTL, uintptr(fd), request, uintptr(arg))
+	if errno != 0 {
+		return errno
+	}
+	return nil
+}
+
+// makeRaw puts the terminal into raw mode so keys are read one at a time
+// without echo. Reads time out after a tenth of a second so the caller can
+// poll for resizes between key presses.
+func makeRaw(fd int) (*terminalState, error) {
+	var state terminalState
+	if err := ioctl(fd, ioctlGetTermios, unsafe.Pointer(&state.termios)); err != nil {
+		return nil, err
+	}
+	raw := state.termios
+	raw.Iflag &^= syscall.IGNBRK | syscall.BRKINT | syscall.PARMRK | syscall.ISTRIP |
+		syscall.INLCR | syscall.IGNCR | syscall.ICRNL | syscall.IXON
+	raw.Lflag &^= syscall.ECHO | syscall.ECHONL | syscall.ICANON | syscall.ISIG | syscall.IEXTEN
+	raw.Cc[syscall.VMIN] = 0
+	raw.Cc[syscall.VTIME] = 1
+	if err := ioctl(fd, ioctlSetTermios, unsafe.Pointer(&raw)); err != nil {
+		return nil, err
+	}
+	return &state, nil
+}
+
+func restoreTerminal(fd int, state *terminalState) error {
+	return ioctl(fd, ioctlSetTermios, unsafe.Pointer(&state.termios))
+}
+
+// terminalSize returns the width and height of the terminal in cells.
+func terminalSize(fd int) (int, int, error) {
+	var size struct {
+		rows, cols, x, y uint16
+	}
+	if err := ioctl(fd, syscall.TIOCGWINSZ, unsafe.Pointer(&size)); err != nil {
+		return 0, 0, err
+	}
+	return int(size.cols), int(size.rows), nil
+}
+
+// notifyResize delivers a value on the returned channel whenever the terminal
+// is resized. stop must be called to release the signal handler.
+func notifyResize() (<-chan os.Signal, func()) {
+	resized := make(chan os.Signal, 1)
+	signal.Notify(resized, syscall.SIGWINCH)
+	return resized, func() { signal.Stop(resized) }
+}
@@ -0,0 +1,239 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// badges are earned in order, one for every badgeEvery species caught.
+var badges = []string{"Boulder", "Cascade", "Thunder", "Rainbow", "Soul", "Marsh", "Volcano", "Earth"}
+
+const badgeEvery = 10
+
+// trainerState is the trainer playing this session. A trainer without a name
+// hasn't been saved yet, and their progress is lost on exit unless they're
+// saved with `trainer new`.
+type trainerState struct {
+	name         string
+	started      time.Time
+	played       time.Duration
+	sessionStart time.Time
+	throws       int
+	catches      int
+}
+
+// playTime returns the time played in earlier sessions plus this one.
+func (t trainerState) playTime() time.Duration {
+	if t.sessionStart.IsZero() {
+		return t.played
+	}
+	return t.played + time.Since(t.sessionStart)
+}
+
+// earnedBadges returns the badges earned for catching caught species.
+func earnedBadges(caught int) []string {
+	return badges[:min(caught/badgeEvery, len(badges))]
+}
+
+// saveTrainer writes the current trainer's save file. Unsaved trainers are
+// skipped.
+func (config *commandConfig) saveTrainer() error {
+	if config.trainer.name == "" {
+		return nil
+	}
+	if err := writeSave(config.toSaveFile()); err != nil {
+		return fmt.Errorf("error saving trainer %v: %w", config.trainer.name, err)
+	}
+	return nil
+}
+
+// loadLastTrainer resumes the trainer played last, if there is one. The
+// trainer is loaded even when some of their pokemon can't be fetched, and the
+// error is returned to warn about.
+func loadLastTrainer(ctx context.Context, config *commandConfig) error {
+	config.trainer.sessionStart = time.Now()
+	name := lastTrainer()
+	if name == "" {
+		return nil
+	}
+	save, err := readSave(name)
+	if err != nil {
+		return err
+	}
+	err = fetchSavedPokemon(ctx, &save)
+	config.loadSaveFile(save)
+	return err
+}
+
+func commandTrainer(ctx context.Context, config *commandConfig, args []string) error {
+	if len(args) == 0 {
+		printTrainerCard(config)
+		return nil
+	}
+	switch args[0] {
+	case "card":
+		printTrainerCard(config)
+	case "list":
+		return trainerList(config)
+	case "new", "switch", "delete":
+		if len(args) < 2 {
+			fmt.Printf("usage: trainer %v <name>\n", args[0])
+			return nil
+		}
+		if !validTrainerName(args[1]) {
+			fmt.Println("trainer names may only use letters, numbers, - and _")
+			return nil
+		}
+		switch args[0] {
+		case "new":
+			return trainerNew(config, args[1])
+		case "switch":
+			return trainerSwitch(ctx, config, args[1])
+		}
+		return trainerDelete(config, args[1])
+	default:
+		fmt.Println("usage: trainer [card | list | new <name> | switch <name> | delete <name>]")
+	}
+	return nil
+}
+
+// trainerNew creates a trainer. Progress made before any trainer was created
+// is kept by the new trainer; otherwise the current trainer is saved and the
+// new one starts from scratch.
+func trainerNew(config *commandConfig, name string) error {
+	if saves, err := listSaves(); err == nil {
+		for _, existing := range saves {
+			if existing == name {
+				fmt.Printf("there's already a trainer named %v\n", name)
+				return nil
+			}
+		}
+	}
+	if config.trainer.name != "" {
+		if err := config.saveTrainer(); err != nil {
+			fmt.Println(err)
+			return err
+		}
+		config.loadSaveFile(saveFile{})
+	}
+	config.trainer.name = name
+	config.trainer.started = time.Now()
+	if err := config.saveTrainer(); err != nil {
+		fmt.Println(err)
+		return err
+	}
+	fmt.Printf("Welcome, %v! Your progress will be saved automatically\n", name)
+	return nil
+}
+
+func trainerSwitch(ctx context.Context, config *commandConfig, name string) error {
+	if name == config.trainer.name {
+		fmt.Printf("You're already playing as %v\n", name)
+		return nil
+	}
+	save, err := readSave(name)
+	if err != nil {
+		fmt.Println(err)
+		return nil
+	}
+	if config.trainer.name == "" && (len(config.caught) > 0 || len(config.seen) > 0) {
+		if !confirm(config, "Your progress hasn't been saved to a trainer. Switch anyway?") {
+			fmt.Println("Switch cancelled")
+			return nil
+		}
+	}
+	if err := fetchSavedPokemon(ctx, &save); err != nil {
+		fmt.Printf("Some of %v's pokemon couldn't be fetched, %v\n", name, err)
+	}
+	if err := config.saveTrainer(); err != nil {
+		fmt.Println(err)
+		return err
+	}
+	config.loadSaveFile(save)
+	if err := config.saveTrainer(); err != nil {
+		fmt.Println(err)
+		return err
+	}
+	fmt.Printf("Welcome back, %v!\n", name)
+	return nil
+}
+
+func trainerDelete(config *commandConfig, name string) error {
+	if name == config.trainer.name {
+		fmt.Println("You can't delete the trainer you're playing as, switch to another first")
+		return nil
+	}
+	if !confirm(config, fmt.Sprintf("Delete %v and everything they've caught?", name)) {
+		fmt.Println("Delete cancelled")
+		return nil
+	}
+	if err := deleteSave(name); err != nil {
+		fmt.Println(err)
+		return nil
+	}
+	fmt.Printf("%v was deleted\n", name)
+	return nil
+}
+
+func trainerList(config *commandConfig) error {
+	names, err := listSaves()
+	if err != nil {
+		fmt.Println(err)
+		return err
+	}
+	fmt.Println("Trainers:")
+	if len(names) == 0 {
+		fmt.Println("  - <none>, create one with `trainer new <name>`")
+	}
+	for _, name := range names {
+		if name == config.trainer.name {
+			fmt.Printf("  - %v (playing)\n", name)
+			continue
+		}
+		save, err := readSave(name)
+		if err != nil {
+			fmt.Printf("  - %v (%v)\n", name, err)
+			continue
+		}
+		fmt.Printf("  - %v: %d caught, %v played\n", name, len(save.Caught), formatPlayTime(save.PlayTime))
+	}
+	return nil
+}
+
+func printTrainerCard(config *commandConfig) {
+	t := config.trainer
+	name := t.name
+	if name == "" {
+		name = "<unsaved>"
+	}
+	fmt.Printf("Trainer: %v\n", name)
+	if !t.started.IsZero() {
+		fmt.Printf("Started: %v\n", t.started.Format("2006-01-02"))
+	}
+	fmt.Printf("Play time: %v\n", formatPlayTime(t.playTime()))
+
+	earned := earnedBadges(len(config.caught))
+	if len(earned) == 0 {
+		fmt.Printf("Badges: <none yet, catch %d species for the first>\n", badgeEvery)
+	} else {
+		fmt.Printf("Badges: %v (%d/%d)\n", strings.Join(earned, ", "), len(earned), len(badges))
+	}
+	fmt.Printf("Pokedex: %d seen, %d caught\n", len(config.seen), len(config.caught))
+	fmt.Printf("Pokemon owned: %d\n", len(config.storage.all()))
+
+	rate := 0.0
+	if t.throws > 0 {
+		rate = 100 * float64(t.catches) / float64(t.throws)
+	}
+	fmt.Printf("Pokeballs thrown: %d, caught: %d (%.0f%%)\n", t.throws, t.catches, rate)
+	if t.name == "" {
+		fmt.Println("Save your progress with `trainer new <name>`")
+	}
+}
+
+// formatPlayTime formats d as hours and minutes.
+func formatPlayTime(d time.Duration) string {
+	return fmt.Sprintf("%dh %02dm", int(d.Hours()), int(d.Minutes())%60)
+}
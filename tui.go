@@ -0,0 +1,421 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// tuiPane identifies one of the panes of the full-screen interface.
+type tuiPane int
+
+const (
+	locationsPane tuiPane = iota
+	encountersPane
+	detailsPane
+	pokedexPane
+	paneCount
+)
+
+var paneTitles = [paneCount]string{"Locations", "Encounters", "Details", "Pokedex"}
+
+const tuiHelp = "tab: switch pane  ↑/↓: move  enter: open  n/p: next/prev page  c: catch  /: search  q: quit"
+
+// tuiList is the content of a pane along with the selected line and how far
+// it has been scrolled. keys, when set, holds what to look each item up by:
+// the owned id in the pokedex pane.
+type tuiList struct {
+	items    []string
+	keys     []string
+	selected int
+	offset   int
+}
+
+// tui is the full-screen interface. It drives the same commands as the REPL
+// and shows their results in panes instead of printing them in sequence.
+type tui struct {
+	ctx       context.Context
+	config    *commandConfig
+	out       io.Writer
+	panes     [paneCount]tuiList
+	focus     tuiPane
+	filter    string
+	searching bool
+	status    string
+	width     int
+	height    int
+}
+
+// captureOutput runs fn with standard output redirected and returns what it
+// printed, so command implementations can be reused to fill panes.
+func captureOutput(fn func()) string {
+	r, w, err := os.Pipe()
+	if err != nil {
+		return err.Error()
+	}
+	stdout := os.Stdout
+	os.Stdout = w
+	printed := make(chan string)
+	go func() {
+		data, _ := io.ReadAll(r)
+		printed <- string(data)
+	}()
+	fn()
+	w.Close()
+	os.Stdout = stdout
+	return <-printed
+}
+
+func commandTUI(ctx context.Context, config *commandConfig, args []string) error {
+	if err := runTUI(ctx, config); err != nil {
+		fmt.Println(err)
+		return err
+	}
+	return nil
+}
+
+func runTUI(ctx context.Context, config *commandConfig) error {
+	fd := int(os.Stdin.Fd())
+	state, err := makeRaw(fd)
+	if err != nil {
+		return fmt.Errorf("error starting full-screen mode: %w", err)
+	}
+	defer restoreTerminal(fd, state)
+	resized, stopResize := notifyResize()
+	defer stopResize()
+
+	// Commands can't prompt for confirmation while keys are read raw.
+	input := config.input
+	config.input = nil
+	defer func() { config.input = input }()
+
+	t := &tui{ctx: ctx, config: config, out: os.Stdout, status: tuiHelp}
+	t.resize()
+	fmt.Fprint(t.out, "\x1b[?1049h\x1b[?25l")
+	defer fmt.Fprint(t.out, "\x1b[?25h\x1b[?1049l")
+
+	t.loadLocations(nextLocationAreas)
+	t.refreshPokedex()
+	buf := make([]byte, 16)
+	for {
+		t.draw()
+		n, err := os.Stdin.Read(buf)
+		if err != nil && err != io.EOF {
+			return err
+		}
+		select {
+		case <-resized:
+			t.resize()
+		default:
+		}
+		if n == 0 {
+			continue
+		}
+		if quit := t.handleKey(string(buf[:n])); quit {
+			return nil
+		}
+	}
+}
+
+func (t *tui) resize() {
+	width, height, err := terminalSize(int(os.Stdin.Fd()))
+	if err != nil || width < 40 || height < 10 {
+		width, height = 80, 24
+	}
+	t.width, t.height = width, height
+}
+
+// handleKey reacts to a key press and reports whether the TUI should exit.
+func (t *tui) handleKey(key string) bool {
+	if t.searching {
+		t.handleSearchKey(key)
+		return false
+	}
+	switch key {
+	case "q", "\x03":
+		return true
+	case "\t":
+		t.setFocus((t.focus + 1) % paneCount)
+	case "\x1b[Z":
+		t.setFocus((t.focus + paneCount - 1) % paneCount)
+	case "\x1b[A", "\x1bOA", "k":
+		t.move(-1)
+	case "\x1b[B", "\x1bOB", "j":
+		t.move(1)
+	case "\x1b[5~":
+		t.move(-t.paneHeight(t.focus))
+	case "\x1b[6~":
+		t.move(t.paneHeight(t.focus))
+	case "n":
+		t.loadLocations(nextLocationAreas)
+	case "p":
+		if onFirstLocationPage(t.config) {
+			t.status = "you're on the first page"
+			return false
+		}
+		t.loadLocations(previousLocationAreas)
+	case "\r", "\n":
+		t.open()
+	case "c":
+		t.catch()
+	case "/":
+		t.searching = true
+		t.filter = ""
+	case "\x1b":
+		t.filter = ""
+		t.status = tuiHelp
+	}
+	return false
+}
+
+func (t *tui) handleSearchKey(key string) {
+	switch key {
+	case "\r", "\n":
+		t.searching = false
+	case "\x1b", "\x03":
+		t.searching = false
+		t.filter = ""
+	case "\x7f", "\b":
+		if t.filter != "" {
+			_, size := utf8.DecodeLastRuneInString(t.filter)
+			t.filter = t.filter[:len(t.filter)-size]
+		}
+	default:
+		if !strings.HasPrefix(key, "\x1b") {
+			t.filter += key
+		}
+	}
+	list := &t.panes[t.focus]
+	list.selected, list.offset = 0, 0
+	t.status = "search: " + t.filter
+}
+
+func (t *tui) setFocus(pane tuiPane) {
+	t.focus = pane
+	t.filter = ""
+	t.status = tuiHelp
+}
+
+// visibleItems returns the indexes of the items in pane that match the
+// search filter. Only the focused pane is filtered.
+func (t *tui) visibleItems(pane tuiPane) []int {
+	indexes := []int{}
+	for i, item := range t.panes[pane].items {
+		if pane != t.focus || t.filter == "" || strings.Contains(item, t.filter) {
+			indexes = append(indexes, i)
+		}
+	}
+	return indexes
+}
+
+func (t *tui) move(delta int) {
+	list := &t.panes[t.focus]
+	count := len(t.visibleItems(t.focus))
+	list.selected = max(0, min(count-1, list.selected+delta))
+	height := t.paneHeight(t.focus)
+	if list.selected < list.offset {
+		list.offset = list.selected
+	}
+	if list.selected >= list.offset+height {
+		list.offset = list.selected - height + 1
+	}
+}
+
+// selectedItem returns the key of the selected item of pane, taking the
+// search filter into account.
+func (t *tui) selectedItem(pane tuiPane) (string, bool) {
+	visible := t.visibleItems(pane)
+	list := t.panes[pane]
+	if list.selected >= len(visible) {
+		return "", false
+	}
+	if list.keys != nil {
+		return list.keys[visible[list.selected]], true
+	}
+	return list.items[visible[list.selected]], true
+}
+
+func (t *tui) setItems(pane tuiPane, items []string) {
+	t.panes[pane] = tuiList{items: items}
+}
+
+func (t *tui) loading(what string) {
+	t.status = "loading " + what + "..."
+	t.draw()
+}
+
+func (t *tui) loadLocations(load func(ctx context.Context, config *commandConfig) ([]string, error)) {
+	t.loading("locations")
+	names, err := load(t.ctx, t.config)
+	if err != nil {
+		t.status = err.Error()
+		return
+	}
+	t.setItems(locationsPane, names)
+	t.status = fmt.Sprintf("page %d of %d  |  %v", t.config.locations.Current(), t.config.locations.Pages(), tuiHelp)
+}
+
+func (t *tui) refreshPokedex() {
+	items, keys := []string{}, []string{}
+	for _, owned := range t.config.storage.all() {
+		items = append(items, owned.displayName())
+		keys = append(keys, strconv.Itoa(owned.ID))
+	}
+	t.panes[pokedexPane] = tuiList{items: items, keys: keys}
+}
+
+// open explores the selected location or shows details of the selected
+// pokemon, depending on the focused pane.
+func (t *tui) open() {
+	item, ok := t.selectedItem(t.focus)
+	if !ok {
+		return
+	}
+	switch t.focus {
+	case locationsPane:
+		t.loading(item)
+		names, warning, err := exploreArea(t.ctx, t.config, item)
+		if err != nil {
+			t.status = err.Error()
+			return
+		}
+		t.setItems(encountersPane, names)
+		t.setFocus(encountersPane)
+		if warning != nil {
+			t.status = "some pokemon are marked seen by form, " + warning.Error()
+		}
+	case encountersPane:
+		t.showDetails(item)
+	case pokedexPane:
+		t.showOwned(item)
+	}
+}
+
+func (t *tui) showDetails(name string) {
+	t.loading(name)
+	var output string
+	if _, ok := t.config.storage.find(name); ok {
+		output = captureOutput(func() { commandInspect(t.ctx, t.config, []string{name}) })
+	} else {
+		output = captureOutput(func() { commandSprite(t.ctx, t.config, []string{name, "--mode=ascii", "--width=32"}) })
+		output += "Not caught yet. Press c in the encounters pane to try catching it.\n"
+	}
+	t.setItems(detailsPane, strings.Split(strings.TrimRight(output, "\n"), "\n"))
+	t.status = tuiHelp
+}
+
+// showOwned shows the details of the owned pokemon with the given id, so
+// each of several pokemon of the same species opens its own.
+func (t *tui) showOwned(id string) {
+	n, _ := strconv.Atoi(id)
+	loc, ok := t.config.storage.findID(n)
+	if !ok {
+		t.status = "that pokemon is no longer in your collection"
+		return
+	}
+	owned := t.config.storage.get(loc)
+	t.loading(owned.displayName())
+	output := captureOutput(func() { inspectOwned(t.ctx, t.config, owned, commandFlags{}) })
+	t.setItems(detailsPane, strings.Split(strings.TrimRight(output, "\n"), "\n"))
+	t.status = tuiHelp
+}
+
+func (t *tui) catch() {
+	if t.focus != encountersPane {
+		t.status = "select a pokemon in the encounters pane to catch it"
+		return
+	}
+	name, ok := t.selectedItem(encountersPane)
+	if !ok {
+		return
+	}
+	t.loading(name)
+	output := captureOutput(func() { commandCatch(t.ctx, t.config, []string{name}) })
+	t.setItems(detailsPane, strings.Split(strings.TrimRight(output, "\n"), "\n"))
+	t.refreshPokedex()
+	t.status = tuiHelp
+}
+
+// paneRect returns the position and size of a pane including its border.
+// Locations and encounters share the left half; details and the pokedex
+// split the right half.
+func (t *tui) paneRect(pane tuiPane) (x, y, w, h int) {
+	body := t.height - 1
+	left := t.width / 4
+	switch pane {
+	case locationsPane:
+		return 0, 0, left, body
+	case encountersPane:
+		return left, 0, t.width/2 - left, body
+	case detailsPane:
+		return t.width / 2, 0, t.width - t.width/2, body * 2 / 3
+	}
+	return t.width / 2, body * 2 / 3, t.width - t.width/2, body - body*2/3
+}
+
+// paneHeight returns the number of lines that fit inside a pane's border.
+func (t *tui) paneHeight(pane tuiPane) int {
+	_, _, _, h := t.paneRect(pane)
+	return max(1, h-2)
+}
+
+func (t *tui) draw() {
+	var screen strings.Builder
+	screen.WriteString("\x1b[H\x1b[2J")
+	for pane := tuiPane(0); pane < paneCount; pane++ {
+		t.drawPane(&screen, pane)
+	}
+	status := t.status
+	if t.searching {
+		status = "search: " + t.filter + "█"
+	}
+	fmt.Fprintf(&screen, "\x1b[%d;1H\x1b[7m%v\x1b[0m", t.height, fit(status, t.width))
+	fmt.Fprint(t.out, screen.String())
+}
+
+func (t *tui) drawPane(screen *strings.Builder, pane tuiPane) {
+	x, y, w, h := t.paneRect(pane)
+	inner := w - 2
+	title := paneTitles[pane]
+	if pane == t.focus {
+		title = "[" + title + "]"
+		if t.filter != "" {
+			title += " /" + t.filter
+		}
+	}
+	title = fit("─"+title, min(inner, utf8.RuneCountInString(title)+1))
+	border := strings.Repeat("─", max(0, inner-utf8.RuneCountInString(title)))
+	fmt.Fprintf(screen, "\x1b[%d;%dH┌%v%v┐", y+1, x+1, title, border)
+
+	list := t.panes[pane]
+	visible := t.visibleItems(pane)
+	for row := 0; row < h-2; row++ {
+		line := ""
+		index := list.offset + row
+		if index < len(visible) {
+			line = list.items[visible[index]]
+		}
+		line = fit(line, inner)
+		if index == list.selected && pane == t.focus && pane != detailsPane && index < len(visible) {
+			line = "\x1b[7m" + line + "\x1b[0m"
+		}
+		fmt.Fprintf(screen, "\x1b[%d;%dH│%v│", y+row+2, x+1, line)
+	}
+	fmt.Fprintf(screen, "\x1b[%d;%dH└%v┘", y+h, x+1, strings.Repeat("─", max(0, inner)))
+}
+
+// fit truncates or pads s to exactly width cells.
+func fit(s string, width int) string {
+	if width <= 0 {
+		return ""
+	}
+	runes := []rune(strings.ReplaceAll(s, "\t", "  "))
+	if len(runes) > width {
+		return string(runes[:width])
+	}
+	return string(runes) + strings.Repeat(" ", width-len(runes))
+}
@@ -0,0 +1,207 @@
+package main
+
+import (
+	"context"
+	"io"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// newTestTUI returns an 80x24 TUI that draws to nowhere.
+func newTestTUI(config *commandConfig) *tui {
+	return &tui{ctx: context.Background(), config: config, out: io.Discard, status: tuiHelp, width: 80, height: 24}
+}
+
+func TestFit(t *testing.T) {
+	cases := []struct {
+		s        string
+		width    int
+		expected string
+	}{
+		{"abc", 5, "abc  "},
+		{"abcdef", 3, "abc"},
+		{"a\tb", 4, "a  b"},
+		{"pokémon", 4, "poké"},
+		{"─x", 3, "─x "},
+		{"abc", 0, ""},
+		{"abc", -1, ""},
+	}
+	for _, c := range cases {
+		if got := fit(c.s, c.width); got != c.expected {
+			t.Errorf("fit(%q, %d): expected %q, got %q", c.s, c.width, c.expected, got)
+		}
+	}
+}
+
+func TestVisibleItemsFilter(t *testing.T) {
+	tui := newTestTUI(&commandConfig{})
+	tui.setItems(locationsPane, []string{"route-1-area", "pallet-town", "route-2-area"})
+	tui.setItems(encountersPane, []string{"pidgey", "rattata", "pikachu"})
+	tui.focus = encountersPane
+	tui.filter = "pi"
+
+	if got := tui.visibleItems(encountersPane); !slices.Equal(got, []int{0, 2}) {
+		t.Errorf("expected the matching encounters, got %v", got)
+	}
+	if got := tui.visibleItems(locationsPane); !slices.Equal(got, []int{0, 1, 2}) {
+		t.Errorf("expected unfocused panes to be unfiltered, got %v", got)
+	}
+
+	tui.panes[encountersPane].selected = 1
+	if item, ok := tui.selectedItem(encountersPane); !ok || item != "pikachu" {
+		t.Errorf("expected pikachu to be selected, got %q, %v", item, ok)
+	}
+	tui.panes[encountersPane].selected = 2
+	if item, ok := tui.selectedItem(encountersPane); ok {
+		t.Errorf("expected nothing selected past the filtered items, got %q", item)
+	}
+
+	tui.panes[pokedexPane] = tuiList{items: []string{"Sparky (pikachu)", "pikachu"}, keys: []string{"7", "9"}}
+	tui.focus = pokedexPane
+	tui.filter = "pikachu"
+	tui.panes[pokedexPane].selected = 1
+	if item, ok := tui.selectedItem(pokedexPane); !ok || item != "9" {
+		t.Errorf("expected the selected item's key, got %q, %v", item, ok)
+	}
+}
+
+func TestMove(t *testing.T) {
+	tui := newTestTUI(&commandConfig{})
+	items := make([]string, 30)
+	for i := range items {
+		items[i] = "area"
+	}
+	tui.setItems(locationsPane, items)
+	if height := tui.paneHeight(locationsPane); height != 21 {
+		t.Fatalf("expected 21 lines in the locations pane, got %d", height)
+	}
+
+	cases := []struct {
+		delta    int
+		selected int
+		offset   int
+	}{
+		{-1, 0, 0},
+		{20, 20, 0},
+		{1, 21, 1},
+		{100, 29, 9},
+		{-10, 19, 9},
+		{-29, 0, 0},
+	}
+	for _, c := range cases {
+		tui.move(c.delta)
+		list := tui.panes[locationsPane]
+		if list.selected != c.selected || list.offset != c.offset {
+			t.Errorf("move(%d): expected selected %d at offset %d, got %d at %d", c.delta, c.selected, c.offset, list.selected, list.offset)
+		}
+	}
+
+	tui.setItems(locationsPane, nil)
+	tui.move(1)
+	if list := tui.panes[locationsPane]; list.selected != 0 || list.offset != 0 {
+		t.Errorf("expected an empty pane to stay at the top, got %+v", list)
+	}
+}
+
+func TestPaneRect(t *testing.T) {
+	tui := newTestTUI(&commandConfig{})
+	cases := []struct {
+		pane       tuiPane
+		x, y, w, h int
+	}{
+		{locationsPane, 0, 0, 20, 23},
+		{encountersPane, 20, 0, 20, 23},
+		{detailsPane, 40, 0, 40, 15},
+		{pokedexPane, 40, 15, 40, 8},
+	}
+	for _, c := range cases {
+		x, y, w, h := tui.paneRect(c.pane)
+		if x != c.x || y != c.y || w != c.w || h != c.h {
+			t.Errorf("%v: expected %d,%d %dx%d, got %d,%d %dx%d", paneTitles[c.pane], c.x, c.y, c.w, c.h, x, y, w, h)
+		}
+	}
+
+	tui.width, tui.height = 81, 25
+	_, _, leftWidth, _ := tui.paneRect(encountersPane)
+	x, _, w, _ := tui.paneRect(detailsPane)
+	_, y, _, h := tui.paneRect(pokedexPane)
+	if 20+leftWidth != x || x+w != 81 || y+h != 24 {
+		t.Errorf("expected the panes to fill an odd sized screen, got %d+%d, %d+%d, %d+%d", 20, leftWidth, x, w, y, h)
+	}
+}
+
+func TestHandleKey(t *testing.T) {
+	tui := newTestTUI(&commandConfig{})
+	tui.setItems(encountersPane, []string{"pidgey", "rattata", "pikachu"})
+
+	tui.handleKey("\t")
+	if tui.focus != encountersPane {
+		t.Errorf("expected tab to focus the encounters pane, got %v", tui.focus)
+	}
+	tui.handleKey("\x1b[Z")
+	tui.handleKey("\x1b[Z")
+	if tui.focus != pokedexPane {
+		t.Errorf("expected shift-tab to wrap around to the pokedex pane, got %v", tui.focus)
+	}
+	tui.handleKey("c")
+	if !strings.Contains(tui.status, "encounters pane") {
+		t.Errorf("expected catching outside the encounters pane to be refused, got %q", tui.status)
+	}
+	tui.handleKey("\t")
+	tui.handleKey("\t")
+	tui.handleKey("j")
+	if tui.focus != encountersPane || tui.panes[encountersPane].selected != 1 {
+		t.Errorf("expected j to move down the encounters pane, got %+v", tui.panes[encountersPane])
+	}
+
+	for _, key := range []string{"/", "p", "i", "k", "\x7f", "\x1b[A", "\r"} {
+		if quit := tui.handleKey(key); quit {
+			t.Fatalf("expected %q not to quit while searching", key)
+		}
+	}
+	if tui.searching || tui.filter != "pi" || tui.status != "search: pi" {
+		t.Errorf("expected the search to end with the filter pi, got %q searching %v", tui.filter, tui.searching)
+	}
+	if list := tui.panes[encountersPane]; list.selected != 0 || list.offset != 0 {
+		t.Errorf("expected searching to reset the selection, got %+v", list)
+	}
+	if item, _ := tui.selectedItem(encountersPane); item != "pidgey" {
+		t.Errorf("expected pidgey to be selected, got %q", item)
+	}
+
+	tui.handleKey("\x1b")
+	if tui.filter != "" || tui.status != tuiHelp {
+		t.Errorf("expected escape to clear the filter, got %q", tui.filter)
+	}
+	tui.handleKey("/")
+	tui.handleSearchKey("x")
+	tui.handleSearchKey("\x03")
+	if tui.searching || tui.filter != "" {
+		t.Errorf("expected ctrl-c to cancel the search, got %q searching %v", tui.filter, tui.searching)
+	}
+	if !tui.handleKey("q") || !tui.handleKey("\x03") {
+		t.Error("expected q and ctrl-c to quit")
+	}
+}
+
+func TestOpenOwnedPokemon(t *testing.T) {
+	var config commandConfig
+	first, second := newOwned(1, "pikachu"), newOwned(2, "pikachu")
+	first.Nickname, second.Nickname = "Sparky", "Volt"
+	config.storage.add(first)
+	config.storage.add(second)
+	tui := newTestTUI(&config)
+	tui.refreshPokedex()
+	tui.focus = pokedexPane
+
+	for i, nickname := range []string{"Sparky", "Volt"} {
+		tui.focus = pokedexPane
+		tui.panes[pokedexPane].selected = i
+		tui.open()
+		details := strings.Join(tui.panes[detailsPane].items, "\n")
+		if !strings.Contains(details, "Nickname: "+nickname+"\n") {
+			t.Errorf("expected row %d to show %v, got:\n%v", i, nickname, details)
+		}
+	}
+}
@@ -0,0 +1,75 @@
+package main
+
+import (
+	"context"
+
+	"github.com/zorahscope/pokedexcli/internal/pokeapi"
+)
+
+// battleTypes are the types pokemon and their moves can have.
+var battleTypes = []string{
+	"normal", "fire", "water", "electric", "grass", "ice", "fighting", "poison", "ground",
+	"flying", "psychic", "bug", "rock", "ghost", "dragon", "dark", "steel", "fairy",
+}
+
+// typeChart maps an attacking type to the damage multiplier it has against
+// each defending type.
+type typeChart map[string]map[string]float64
+
+// fetchTypeChart builds the type chart from the damage relations of every
+// battle type.
+func fetchTypeChart(ctx context.Context) (typeChart, error) {
+	urls := make([]string, len(battleTypes))
+	for i, name := range battleTypes {
+		urls[i] = pokeapi.BaseURL + "type/" + name
+	}
+	types, err := pokeapi.GetAll[pokeapi.Type](ctx, pokeapi.DefaultClient, urls)
+	if err != nil {
+		return nil, err
+	}
+	chart := make(typeChart, len(types))
+	for _, t := range types {
+		chart[t.Name] = damageTo(t.DamageRelations)
+	}
+	return chart, nil
+}
+
+// damageTo returns the multiplier of an attack against every battle type
+// given the attacking type's damage relations.
+func damageTo(relations pokeapi.TypeRelations) map[string]float64 {
+	row := make(map[string]float64, len(battleTypes))
+	for _, name := range battleTypes {
+		row[name] = 1
+	}
+	for _, t := range relations.DoubleDamageTo {
+		row[t.Name] = 2
+	}
+	for _, t := range relations.HalfDamageTo {
+		row[t.Name] = 0.5
+	}
+	for _, t := range relations.NoDamageTo {
+		row[t.Name] = 0
+	}
+	return row
+}
+
+// effectiveness returns the damage multiplier of an attack of the attacking
+// type against a pokemon with the defending types.
+func (c typeChart) effectiveness(attacking string, defending []string) float64 {
+	multiplier := 1.0
+	for _, t := range defending {
+		if m, ok := c[attacking][t]; ok {
+			multiplier *= m
+		}
+	}
+	return multiplier
+}
+
+// pokemonTypes returns the names of a pokemon's types.
+func pokemonTypes(pkmn pokeapi.Pokemon) []string {
+	types := make([]string, len(pkmn.Types))
+	for i, t := range pkmn.Types {
+		types[i] = t.Type.Name
+	}
+	return types
+}
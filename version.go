@@ -0,0 +1,126 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/zorahscope/pokedexcli/internal/pokeapi"
+)
+
+// gameVersion is the game the session is scoped to with the version command.
+type gameVersion struct {
+	name         string
+	versionGroup string
+	generation   int
+	pokedexes    []string
+}
+
+// versionName returns the name of the session's game version, or "" when
+// none is set.
+func (config *commandConfig) versionName() string {
+	if config.version == nil {
+		return ""
+	}
+	return config.version.name
+}
+
+// versionGroupName returns the version group of the session's game version,
+// or "" when none is set.
+func (config *commandConfig) versionGroupName() string {
+	if config.version == nil {
+		return ""
+	}
+	return config.version.versionGroup
+}
+
+func commandVersion(ctx context.Context, config *commandConfig, args []string) error {
+	if len(args) == 0 {
+		if config.version == nil {
+			fmt.Println("No game version set, data from every version is shown")
+			return nil
+		}
+		fmt.Printf("Playing %v (%v, generation %d)\n", config.version.name, config.version.versionGroup, config.version.generation)
+		return nil
+	}
+	if args[0] == "clear" || args[0] == "all" {
+		config.version = nil
+		fmt.Println("Game version cleared, data from every version is shown")
+		return nil
+	}
+
+	version, err := fetchGameVersion(ctx, args[0])
+	if err != nil {
+		fmt.Println(err)
+		return err
+	}
+	config.version = version
+	fmt.Printf("Now playing %v (%v, generation %d)\n", version.name, version.versionGroup, version.generation)
+	return nil
+}
+
+// fetchGameVersion looks up a game version along with its version group.
+func fetchGameVersion(ctx context.Context, name string) (*gameVersion, error) {
+	version, err := pokeapi.GetVersion(ctx, name)
+	if errors.Is(err, pokeapi.ErrNotFound) {
+		return nil, fmt.Errorf("%v isn't a known game version", name)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error getting data from API: %w", err)
+	}
+	group, err := pokeapi.GetVersionGroup(ctx, version.VersionGroup.Name)
+	if err != nil {
+		return nil, fmt.Errorf("error getting data from API: %w", err)
+	}
+	pokedexes := make([]string, 0, len(group.Pokedexes))
+	for _, pokedex := range group.Pokedexes {
+		pokedexes = append(pokedexes, pokedex.URL)
+	}
+	return &gameVersion{
+		name:         version.Name,
+		versionGroup: group.Name,
+		generation:   group.Generation.ID(),
+		pokedexes:    pokedexes,
+	}, nil
+}
+
+// typesIn returns a pokemon's types as they were in the given generation.
+// PastTypes lists the types a pokemon had up to and including a generation,
+// so the earliest entry at or after generation applies.
+func typesIn(pkmn pokeapi.Pokemon, generation int) []string {
+	best := -1
+	for i, past := range pkmn.PastTypes {
+		id := past.Generation.ID()
+		if id >= generation && (best == -1 || id < pkmn.PastTypes[best].Generation.ID()) {
+			best = i
+		}
+	}
+	types := []string{}
+	if best == -1 {
+		for _, t := range pkmn.Types {
+			types = append(types, t.Type.Name)
+		}
+		return types
+	}
+	for _, t := range pkmn.PastTypes[best].Types {
+		types = append(types, t.Type.Name)
+	}
+	return types
+}
+
+// availableIn reports whether a pokemon's species is in any of the pokedexes
+// of version.
+func availableIn(ctx context.Context, pkmn pokeapi.Pokemon, version *gameVersion) (bool, error) {
+	pokedexes, err := pokeapi.GetAll[pokeapi.Pokedex](ctx, pokeapi.DefaultClient, version.pokedexes)
+	if err != nil {
+		return false, fmt.Errorf("error getting data from API: %w", err)
+	}
+	for _, pokedex := range pokedexes {
+		for _, entry := range pokedex.PokemonEntries {
+			if entry.PokemonSpecies.Name == pkmn.Species.Name {
+				return true, nil
+			}
+		}
+	}
+	return false, nil
+}
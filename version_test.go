@@ -0,0 +1,46 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/zorahscope/pokedexcli/internal/pokeapi"
+)
+
+func TestTypesIn(t *testing.T) {
+	// Clefairy was normal type up to generation 5 and fairy type since.
+	data := `{
+		"name": "clefairy",
+		"types": [{"slot": 1, "type": {"name": "fairy"}}],
+		"past_types": [{
+			"generation": {"name": "generation-v", "url": "https://pokeapi.co/api/v2/generation/5/"},
+			"types": [{"slot": 1, "type": {"name": "normal"}}]
+		}]
+	}`
+	var pkmn pokeapi.Pokemon
+	if err := json.Unmarshal([]byte(data), &pkmn); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	cases := map[int]string{1: "normal", 5: "normal", 6: "fairy", 9: "fairy"}
+	for generation, want := range cases {
+		if got := strings.Join(typesIn(pkmn, generation), "/"); got != want {
+			t.Errorf("typesIn(clefairy, %d) = %v, want %v", generation, got, want)
+		}
+	}
+}
+
+func TestFetchGameVersionErrors(t *testing.T) {
+	serveAPI(t, map[string]string{"version/broken": `{"name": `})
+
+	_, err := fetchGameVersion(context.Background(), "pokemon-beige")
+	if err == nil || err.Error() != "pokemon-beige isn't a known game version" {
+		t.Errorf("expected an unknown version error, got %v", err)
+	}
+	_, err = fetchGameVersion(context.Background(), "broken")
+	if err == nil || !strings.HasPrefix(err.Error(), "error getting data from API: ") {
+		t.Errorf("expected an API error, got %v", err)
+	}
+}
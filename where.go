@@ -0,0 +1,80 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/zorahscope/pokedexcli/internal/pokeapi"
+)
+
+// habitatTable groups the location areas a pokemon can be found in by
+// version.
+func habitatTable(encounters []pokeapi.LocationAreaEncounter, version string) []versionEncounters {
+	tables := newEncounterTables(version, "")
+	for _, encounter := range encounters {
+		for _, detail := range encounter.VersionDetails {
+			tables.add(encounter.LocationArea.Name, detail)
+		}
+	}
+	return tables.sorted()
+}
+
+func commandWhere(ctx context.Context, config *commandConfig, args []string) error {
+	args, flags := parseFlags(args, "version")
+	if len(args) == 0 {
+		fmt.Println("No pokemon selected! Please try again")
+		return nil
+	}
+
+	pkmn, err := lookupPokemon(ctx, config, args[0])
+	if errors.Is(err, pokeapi.ErrNotFound) {
+		fmt.Println(err)
+		return nil
+	}
+	if err != nil {
+		fmt.Println(err)
+		return err
+	}
+	encounters, err := pokeapi.GetFromAPI[[]pokeapi.LocationAreaEncounter](ctx, pkmn.LocationAreaEncounters)
+	if err != nil {
+		fmt.Printf("error getting data from API: %v\n", err)
+		return fmt.Errorf("error getting data from API: %w", err)
+	}
+
+	version := flags.get("version", config.versionName())
+	tables := habitatTable(encounters, version)
+	if len(tables) == 0 {
+		if version == "" {
+			fmt.Printf("%v can't be found in the wild\n", pkmn.Name)
+			return nil
+		}
+		fmt.Printf("%v can't be found in the wild in %v\n", pkmn.Name, version)
+		versions := []string{}
+		for _, table := range habitatTable(encounters, "") {
+			versions = append(versions, table.version)
+		}
+		if len(versions) > 0 {
+			fmt.Println("Versions with encounters: " + strings.Join(versions, ", "))
+		}
+		return nil
+	}
+	areas := []string{}
+	for _, table := range tables {
+		for _, row := range table.rows {
+			areas = append(areas, row.name)
+		}
+	}
+	if err := config.locale.localizeAreas(ctx, areas); err != nil {
+		fmt.Printf("error getting data from API: %v\n", err)
+		return fmt.Errorf("error getting data from API: %w", err)
+	}
+	if err := config.locale.localizeSpecies(ctx, []pokeapi.Pokemon{pkmn}); err != nil {
+		fmt.Printf("error getting data from API: %v\n", err)
+		return fmt.Errorf("error getting data from API: %w", err)
+	}
+	fmt.Printf("%v can be found in:\n", config.locale.display(pkmn.Name))
+	printEncounterTables(tables, "Location area", config.locale.display)
+	return nil
+}
@@ -0,0 +1,76 @@
+package main
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/zorahscope/pokedexcli/internal/pokeapi"
+)
+
+const testEncounters = `[
+	{"location_area": {"name": "route-1-area"}, "version_details": [
+		{"version": {"name": "red"}, "encounter_details": [
+			{"chance": 30, "min_level": 2, "max_level": 3, "method": {"name": "walk"}},
+			{"chance": 20, "min_level": 4, "max_level": 5, "method": {"name": "walk"}}
+		]},
+		{"version": {"name": "blue"}, "encounter_details": [
+			{"chance": 15, "min_level": 2, "max_level": 2, "method": {"name": "walk"}}
+		]}
+	]},
+	{"location_area": {"name": "viridian-forest-area"}, "version_details": [
+		{"version": {"name": "red"}, "encounter_details": [
+			{"chance": 5, "min_level": 3, "max_level": 4, "method": {"name": "walk"}},
+			{"chance": 10, "min_level": 5, "max_level": 5, "method": {"name": "old-rod"}}
+		]}
+	]},
+	{"location_area": {"name": "cerulean-cave-1f"}, "version_details": [
+		{"version": {"name": "blue"}, "encounter_details": [
+			{"chance": 10, "min_level": 40, "max_level": 45, "method": {"name": "walk"}}
+		]}
+	]}
+]`
+
+func TestHabitatTable(t *testing.T) {
+	var encounters []pokeapi.LocationAreaEncounter
+	if err := json.Unmarshal([]byte(testEncounters), &encounters); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	red := versionEncounters{version: "red", rows: []encounterRow{
+		{name: "viridian-forest-area", method: "old-rod", chance: 10, minLevel: 5, maxLevel: 5},
+		{name: "route-1-area", method: "walk", chance: 50, minLevel: 2, maxLevel: 5},
+		{name: "viridian-forest-area", method: "walk", chance: 5, minLevel: 3, maxLevel: 4},
+	}}
+	blue := versionEncounters{version: "blue", rows: []encounterRow{
+		{name: "route-1-area", method: "walk", chance: 15, minLevel: 2, maxLevel: 2},
+		{name: "cerulean-cave-1f", method: "walk", chance: 10, minLevel: 40, maxLevel: 45},
+	}}
+	cases := []struct {
+		version  string
+		expected []versionEncounters
+	}{
+		{"", []versionEncounters{red, blue}},
+		{"red", []versionEncounters{red}},
+		{"blue", []versionEncounters{blue}},
+		{"gold", nil},
+	}
+	for _, c := range cases {
+		tables := habitatTable(encounters, c.version)
+		if len(tables) != len(c.expected) {
+			t.Errorf("version %q: expected %d tables, got %+v", c.version, len(c.expected), tables)
+			continue
+		}
+		for i, table := range tables {
+			want := c.expected[i]
+			if table.version != want.version || len(table.rows) != len(want.rows) {
+				t.Errorf("version %q: expected %+v, got %+v", c.version, want, table)
+				continue
+			}
+			for j, row := range table.rows {
+				if row != want.rows[j] {
+					t.Errorf("version %q, %v row %d: expected %+v, got %+v", c.version, want.version, j, want.rows[j], row)
+				}
+			}
+		}
+	}
+}